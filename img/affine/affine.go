@@ -7,12 +7,32 @@
 //
 // The affine transformations do not change the image size, they operate on the
 // content only. Implemented affine transformations: translate, zoom, shear and
-// rotate.
+// rotate. ApplyExpand, RotateExpand and ShearExpand instead resize the canvas to
+// fit the whole transformed image.
 //
-// Interpolation functions: Nearest neighbor and Bilinear
+// Projective transformations (homographies) can be estimated from point
+// correspondences with HomographyFromPoints and EstimateHomography, and
+// applied with ApplyHomography, for example to deskew a photographed document.
 //
-// There is experimental Bicubic interpolation, but it has bugs.
-// It seems to work fine for Scale() and ScaleFactor though.
+// Rigid, similarity and affine transformations can be fitted to matched points
+// with EstimateAffine, or with RANSAC when some of the matches are wrong.
+//
+// Non-linear warps are TransformFuncs, which all interpolation functions accept:
+// Undistort for lens distortion, BarrelDistortion, Polar and LogPolar
+// unwrapping, and Remap for a displacement field.
+//
+// Interpolation functions: Nearest neighbor, Bilinear and Bicubic. Bicubic
+// uses the Catmull-Rom kernel, the Interpolate method of a CubicKernel selects
+// another one, such as Mitchell or BSpline.
+//
+// To make images smaller without aliasing, use the Interpolate method of one of
+// the resampling filters Box, Triangle, Lanczos2, Lanczos3 or Gaussian.
+//
+// The pixels beyond the border of the source image are given by a BorderMode:
+// transparent, a constant color, copied, reflected or wrapped around.
+//
+// All interpolation functions split the destination rows over SetWorkers
+// goroutines, with the same result as a serial run.
 //
 // Note: that all the functionality of this package is also available in
 // graphics-go: https://code.google.com/p/graphics-go/.
@@ -25,6 +45,9 @@ import (
 	"math"
 )
 
+// AffineMatrix is a transformation, stored as a 3x3 matrix in row major order.
+// It maps points of the destination image to the source image (the inverse
+// mapping), TransformPoint and TransformRect map the other way.
 type AffineMatrix [9]float64
 
 // NewAffineMatrix returns an identity AffineMatrix.
@@ -112,17 +135,58 @@ func (a *AffineMatrix) AddRotation(θ, xcenter, ycenter float64) {
 //
 // InterpolationFunc f is the interpolation function to be used, thin can be
 // transform.Bilinear, transform.Nearest or a custom interpolation function.
-// Note: affine transforms do not change the canvas size of the image.
+// Note: affine transforms do not change the canvas size of the image, see
+// ApplyExpand for that.
+//
+// If the bottom row of a is not [0, 0, 1] the transformation is projective,
+// and the perspective divide is applied (see also Homography).
 func Apply(a AffineMatrix, src image.Image, interpolate InterpolationFunc) image.Image {
+	return interpolate(src, a.transform(0, 0), src.Bounds().Dx(), src.Bounds().Dy(), BORDER_TRANSPARENT)
+}
+
+// ApplyBorder is Apply with border mode border for the pixels beyond the
+// border of src, for example BORDER_WRAP to tile a texture.
+func ApplyBorder(a AffineMatrix, src image.Image, interpolate InterpolationFunc, border BorderMode) image.Image {
+	return interpolate(src, a.transform(0, 0), src.Bounds().Dx(), src.Bounds().Dy(), border)
+}
+
+// transform returns the TransformFunc of a for a destination canvas with its
+// origin at (dx, dy).
+func (a AffineMatrix) transform(dx, dy int) TransformFunc {
+	a.normalize()
+	projective := a.projective()
 	// Create closure over AffineMatrix a
-	t := func(x, y int) (float64, float64) {
-		X := float64(x) + 0.5
-		Y := float64(y) + 0.5
+	return func(x, y int) (float64, float64) {
+		X := float64(x+dx) + 0.5
+		Y := float64(y+dy) + 0.5
 		nx := X*a[0] + Y*a[1] + a[2]
 		ny := X*a[3] + Y*a[4] + a[5]
+		if projective {
+			w := X*a[6] + Y*a[7] + a[8]
+			nx /= w
+			ny /= w
+		}
 		return nx, ny
 	}
-	return interpolate(src, t, src.Bounds().Dx(), src.Bounds().Dy(), BORDER_TRANSPARENT)
+}
+
+// projective reports whether a needs the perspective divide, because its
+// bottom row is not [0, 0, w].
+func (a *AffineMatrix) projective() bool {
+	return a[6] != 0 || a[7] != 0
+}
+
+// normalize divides an affine (not projective) matrix by a[8], so that its
+// bottom row is [0, 0, 1]. A matrix with a[8] == 0, such as the zero
+// AffineMatrix, is left as it is.
+func (a *AffineMatrix) normalize() {
+	if a.projective() || a[8] == 0 || a[8] == 1 {
+		return
+	}
+	w := a[8]
+	for i := range a {
+		a[i] /= w
+	}
 }
 
 // Normal translation matrix:
@@ -228,6 +292,11 @@ func (a *AffineMatrix) mul(b AffineMatrix) {
 	*a = c
 }
 
+// det returns the determinant of AffineMatrix a.
+func (a *AffineMatrix) det() float64 {
+	return a[0]*(a[4]*a[8]-a[5]*a[7]) + a[1]*(a[5]*a[6]-a[3]*a[8]) + a[2]*(a[3]*a[7]-a[4]*a[6])
+}
+
 // invertMatrix returns the inverse of AffineMatrix a.
 func (a *AffineMatrix) invertMatrix() AffineMatrix {
 	var b AffineMatrix
@@ -0,0 +1,160 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"errors"
+	"image"
+	"math"
+)
+
+// ErrProjective is returned by Decompose when the bottom row of the matrix is
+// not [0, 0, 1].
+var ErrProjective = errors.New("affine: matrix is projective")
+
+// Mul returns the matrix product a * b. As an AffineMatrix stores the inverse
+// mapping, this is the transformation a followed by the transformation b, in
+// the same order as the Add methods chain them.
+func (a AffineMatrix) Mul(b AffineMatrix) AffineMatrix {
+	a.mul(b)
+	return a
+}
+
+// Inverse returns the inverse of a, which maps the source to the destination,
+// or ErrSingular if a has no inverse.
+func (a AffineMatrix) Inverse() (AffineMatrix, error) {
+	det := a.det()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return AffineMatrix{}, ErrSingular
+	}
+	return a.invertMatrix(), nil
+}
+
+// TransformPoint returns the point of the destination image that point p of
+// the source image is mapped to, for example to draw annotations of the source
+// on the transformed image.
+//
+// TransformPoint panics if a is singular, check that a.Inverse does not
+// return ErrSingular first for matrices from user data.
+func (a AffineMatrix) TransformPoint(p Point) Point {
+	inv, err := a.Inverse()
+	if err != nil {
+		panic("affine.TransformPoint: matrix is singular.")
+	}
+	q, _ := project(inv, p)
+	return q
+}
+
+// SourcePoint returns the point of the source image that point p of the
+// destination image is mapped from. It is the inverse of TransformPoint.
+func (a AffineMatrix) SourcePoint(p Point) Point {
+	q, _ := project(a, p)
+	return q
+}
+
+// TransformRect returns the smallest rectangle of the destination image that
+// contains rectangle r of the source image after the transformation.
+//
+// TransformRect panics if a is singular, as TransformPoint, or if a is
+// projective and maps a corner of r to infinity or beyond.
+func (a AffineMatrix) TransformRect(r image.Rectangle) image.Rectangle {
+	inv, err := a.Inverse()
+	if err != nil {
+		panic("affine.TransformRect: matrix is singular.")
+	}
+	b, ok := boundingBox(inv, r)
+	if !ok {
+		panic("affine.TransformRect: rectangle is mapped to infinity.")
+	}
+	return b
+}
+
+// SourceRect returns the smallest rectangle of the source image that contains
+// the pixels that rectangle r of the destination image is mapped from.
+//
+// SourceRect panics if a is projective and maps a corner of r to infinity or
+// beyond.
+func (a AffineMatrix) SourceRect(r image.Rectangle) image.Rectangle {
+	b, ok := boundingBox(a, r)
+	if !ok {
+		panic("affine.SourceRect: rectangle is mapped to infinity.")
+	}
+	return b
+}
+
+// project returns m * p after the perspective divide, and whether p is in
+// front of the projection (w > 0). Every point is in front of an affine
+// matrix.
+func project(m AffineMatrix, p Point) (Point, bool) {
+	m.normalize()
+	q := Point{p.X*m[0] + p.Y*m[1] + m[2], p.X*m[3] + p.Y*m[4] + m[5]}
+	if !m.projective() {
+		return q, true
+	}
+	w := p.X*m[6] + p.Y*m[7] + m[8]
+	q.X /= w
+	q.Y /= w
+	return q, w > 0
+}
+
+// boundingBox returns the smallest rectangle that contains the corners of r
+// mapped by m, or false if a corner is mapped to infinity.
+func boundingBox(m AffineMatrix, r image.Rectangle) (image.Rectangle, bool) {
+	x0, y0 := math.Inf(1), math.Inf(1)
+	x1, y1 := math.Inf(-1), math.Inf(-1)
+	for _, p := range []image.Point{r.Min, {r.Max.X, r.Min.Y}, {r.Min.X, r.Max.Y}, r.Max} {
+		q, ok := project(m, Point{float64(p.X), float64(p.Y)})
+		if !ok {
+			return image.Rectangle{}, false
+		}
+		x0, x1 = math.Min(x0, q.X), math.Max(x1, q.X)
+		y0, y1 = math.Min(y0, q.Y), math.Max(y1, q.Y)
+	}
+	// Round outwards, but not for rounding errors of exact multiples.
+	const ε = 1e-9
+	return image.Rect(int(math.Floor(x0+ε)), int(math.Floor(y0+ε)), int(math.Ceil(x1-ε)), int(math.Ceil(y1-ε))), true
+}
+
+// Decomposition is an affine transformation split into simple ones. The
+// source is first scaled by Sx and Sy, then sheared horizontally by Shear,
+// rotated by Rotation radians and finally translated by Tx and Ty, all
+// around the origin. A negative Sy means that the transformation mirrors.
+//
+// The transformation is rebuilt with:
+//     a := affine.NewAffineMatrix()
+//     a.AddZoom(d.Sx, d.Sy, 0, 0)
+//     a.AddShear(d.Shear, 0, 0, 0)
+//     a.AddRotation(d.Rotation, 0, 0)
+//     a.AddTranslation(d.Tx, d.Ty)
+type Decomposition struct {
+	Tx, Ty   float64
+	Rotation float64
+	Sx, Sy   float64
+	Shear    float64
+}
+
+// Decompose splits the transformation of a into translation, rotation, scale
+// and shear. It returns ErrSingular if a has no inverse, and ErrProjective if
+// a is not affine.
+func (a AffineMatrix) Decompose() (Decomposition, error) {
+	if a.projective() || a[8] == 0 {
+		return Decomposition{}, ErrProjective
+	}
+	a.normalize()
+	m, err := a.Inverse()
+	if err != nil {
+		return Decomposition{}, err
+	}
+	// The forward mapping is m = T * R * H * S. The first column of R * H * S
+	// is R * [Sx, 0], the rest is upper triangular.
+	var d Decomposition
+	d.Tx, d.Ty = m[2], m[5]
+	d.Sx = math.Hypot(m[0], m[3])
+	d.Rotation = math.Atan2(m[3], m[0])
+	c, s := math.Cos(d.Rotation), math.Sin(d.Rotation)
+	d.Sy = -s*m[1] + c*m[4]
+	d.Shear = (c*m[1] + s*m[4]) / d.Sy
+	return d, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func testMatrix() AffineMatrix {
+	a := NewAffineMatrix()
+	a.AddZoom(1.5, 0.8, 16, 12)
+	a.AddRotation(0.3, 16, 12)
+	a.AddShear(0.2, 0, 16, 12)
+	a.AddTranslation(7, -3)
+	return a
+}
+
+func closeMatrix(a, b AffineMatrix, ε float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > ε {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMul(t *testing.T) {
+	a := NewAffineMatrix()
+	a.AddRotation(0.3, 16, 12)
+	b := NewAffineMatrix()
+	b.AddTranslation(7, -3)
+	c := a.Mul(b)
+
+	d := NewAffineMatrix()
+	d.AddRotation(0.3, 16, 12)
+	d.AddTranslation(7, -3)
+	if c != d {
+		t.Errorf("Mul = %v, want %v", c, d)
+	}
+	if id := NewAffineMatrix(); c.Mul(id) != c || id.Mul(c) != c {
+		t.Error("identity is not neutral")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := testMatrix()
+	inv, err := a.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closeMatrix(a.Mul(inv), NewAffineMatrix(), 1e-12) {
+		t.Errorf("a * inverse = %v", a.Mul(inv))
+	}
+	if _, err := (AffineMatrix{1, 2, 0, 2, 4, 0, 0, 0, 1}).Inverse(); err != ErrSingular {
+		t.Errorf("singular: err = %v, want ErrSingular", err)
+	}
+}
+
+func TestTransformPoint(t *testing.T) {
+	a := NewAffineMatrix()
+	a.AddTranslation(10, 5)
+	if p := a.TransformPoint(Point{1, 2}); p != (Point{11, 7}) {
+		t.Errorf("TransformPoint = %v, want (11, 7)", p)
+	}
+
+	// The pixel at the point is moved along with it.
+	src := testPattern()
+	a = testMatrix()
+	m := Apply(a, src, Nearest).(*image.RGBA)
+	p := a.TransformPoint(Point{10.5, 8.5})
+	if got, want := m.RGBAAt(int(p.X), int(p.Y)), src.RGBAAt(10, 8); got != want {
+		t.Errorf("pixel at %v = %v, want %v", p, got, want)
+	}
+
+	q := a.SourcePoint(p)
+	if !closePoint(q, 10.5, 8.5, 1e-12) {
+		t.Errorf("SourcePoint = %v, want (10.5, 8.5)", q)
+	}
+}
+
+func TestTransformRect(t *testing.T) {
+	a := NewAffineMatrix()
+	a.AddZoom(2, 3, 0, 0)
+	a.AddTranslation(-1, 4)
+	r := image.Rect(1, 2, 5, 6)
+	if got, want := a.TransformRect(r), image.Rect(1, 10, 9, 22); got != want {
+		t.Errorf("TransformRect = %v, want %v", got, want)
+	}
+	if got := a.SourceRect(image.Rect(1, 10, 9, 22)); got != r {
+		t.Errorf("SourceRect = %v, want %v", got, r)
+	}
+
+	// A rotated rectangle is enclosed.
+	a = testMatrix()
+	r = image.Rect(0, 0, 32, 24)
+	b := a.TransformRect(r)
+	for _, p := range []Point{{0, 0}, {32, 0}, {0, 24}, {32, 24}, {16, 12}} {
+		q := a.TransformPoint(p)
+		if q.X < float64(b.Min.X) || q.Y < float64(b.Min.Y) || q.X > float64(b.Max.X) || q.Y > float64(b.Max.Y) {
+			t.Errorf("%v is mapped to %v, outside %v", p, q, b)
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	for _, a := range []AffineMatrix{
+		NewAffineMatrix(),
+		testMatrix(),
+		{-0.5, 0.2, 3, 0.1, 2, -4, 0, 0, 1},
+		{2, 0, 0, 0, 2, 0, 0, 0, 2},
+	} {
+		d, err := a.Decompose()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := NewAffineMatrix()
+		b.AddZoom(d.Sx, d.Sy, 0, 0)
+		b.AddShear(d.Shear, 0, 0, 0)
+		b.AddRotation(d.Rotation, 0, 0)
+		b.AddTranslation(d.Tx, d.Ty)
+		for i := range a {
+			b[i] *= a[8]
+		}
+		if !closeMatrix(a, b, 1e-12) {
+			t.Errorf("%v: decomposition %+v gives %v", a, d, b)
+		}
+	}
+
+	a := NewAffineMatrix()
+	a.AddZoom(2, 3, 0, 0)
+	a.AddRotation(0.5, 0, 0)
+	a.AddTranslation(4, 5)
+	d, _ := a.Decompose()
+	if math.Abs(d.Rotation-0.5) > 1e-12 || math.Abs(d.Sx-2) > 1e-12 || math.Abs(d.Sy-3) > 1e-12 || math.Abs(d.Shear) > 1e-12 || math.Abs(d.Tx-4) > 1e-12 || math.Abs(d.Ty-5) > 1e-12 {
+		t.Errorf("Decompose = %+v", d)
+	}
+
+	if _, err := (AffineMatrix(testHomography)).Decompose(); err != ErrProjective {
+		t.Errorf("homography: err = %v, want ErrProjective", err)
+	}
+}
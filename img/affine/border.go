@@ -0,0 +1,134 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image/color"
+)
+
+// BorderMode selects the color of the pixels beyond the border of the source
+// image. For a row abcdefgh the modes extend it as follows:
+//     BORDER_TRANSPARENT   ......|abcdefgh|......  (transparent black)
+//     BORDER_CONSTANT      cccccc|abcdefgh|cccccc  (a constant color c)
+//     BORDER_COPY          aaaaaa|abcdefgh|hhhhhh
+//     BORDER_REFLECT       fedcba|abcdefgh|hgfedc
+//     BORDER_REFLECT101    gfedcb|abcdefgh|gfedcb
+//     BORDER_WRAP          cdefgh|abcdefgh|abcdef
+//
+// The zero BorderMode is BORDER_TRANSPARENT.
+//
+// A BorderMode is a constant: a constant border is its 16 bit premultiplied
+// color, packed as R<<48 | G<<32 | B<<16 | A. The other modes use values that
+// are not a valid premultiplied color, with red but no alpha, and the method
+// in the green bits.
+type BorderMode uint64
+
+type borderMethod int
+
+const (
+	borderConstant borderMethod = iota
+	borderCopy
+	borderReflect
+	borderReflect101
+	borderWrap
+)
+
+const (
+	// Pixels beyond the border are transparent.
+	BORDER_TRANSPARENT BorderMode = 0
+	// Pixels beyond the border are the same as the closest border.
+	BORDER_COPY BorderMode = 1<<48 | BorderMode(borderCopy)<<32
+	// Pixels beyond the border mirror the image, the border pixel included.
+	BORDER_REFLECT BorderMode = 1<<48 | BorderMode(borderReflect)<<32
+	// Pixels beyond the border mirror the image around the border pixel.
+	BORDER_REFLECT101 BorderMode = 1<<48 | BorderMode(borderReflect101)<<32
+	// Pixels beyond the border repeat the image, as for tiling a texture.
+	BORDER_WRAP BorderMode = 1<<48 | BorderMode(borderWrap)<<32
+	// Pixels beyond the border are opaque black, see BorderConstant for
+	// other colors.
+	BORDER_CONSTANT BorderMode = 0xffff
+)
+
+// BorderConstant returns the BorderMode for which the pixels beyond the border
+// have color c.
+func BorderConstant(c color.Color) BorderMode {
+	r, g, b, a := c.RGBA()
+	// Keep the color premultiplied, even if c is not.
+	r, g, b = min32(r, a), min32(g, a), min32(b, a)
+	return BorderMode(r)<<48 | BorderMode(g)<<32 | BorderMode(b)<<16 | BorderMode(a)
+}
+
+func min32(x, y uint32) uint32 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// method returns the way m extends the image.
+func (m BorderMode) method() borderMethod {
+	if m>>48&0xffff > m&0xffff {
+		return borderMethod(m >> 32 & 0xffff)
+	}
+	return borderConstant
+}
+
+// color returns the color of a constant border, transparent black for the
+// other modes.
+func (m BorderMode) color() color.RGBA64 {
+	if m.method() != borderConstant {
+		return color.RGBA64{}
+	}
+	return color.RGBA64{uint16(m >> 48), uint16(m >> 32), uint16(m >> 16), uint16(m)}
+}
+
+// transparent reports whether the pixels beyond the border are transparent
+// black. Destination pixels with all their source pixels beyond the border
+// are then left as they are.
+func (m BorderMode) transparent() bool {
+	return m == BORDER_TRANSPARENT
+}
+
+// index returns the pixel that represents pixel i of a row (or column) of n
+// pixels, or false if it has the constant color of m.
+func (m BorderMode) index(i, n int) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+	if n == 0 {
+		return 0, false
+	}
+	switch m.method() {
+	case borderCopy:
+		return clampIndex(i, n), true
+	case borderReflect:
+		i = mod(i, 2*n)
+		if i >= n {
+			i = 2*n - 1 - i
+		}
+		return i, true
+	case borderReflect101:
+		if n == 1 {
+			return 0, true
+		}
+		i = mod(i, 2*n-2)
+		if i >= n {
+			i = 2*n - 2 - i
+		}
+		return i, true
+	case borderWrap:
+		return mod(i, n), true
+	}
+	return 0, false
+}
+
+// mod returns i modulo n, in [0, n).
+func mod(i, n int) int {
+	i %= n
+	if i < 0 {
+		i += n
+	}
+	return i
+}
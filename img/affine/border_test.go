@@ -0,0 +1,149 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestBorderIndex(t *testing.T) {
+	// Pixels -6 to 9 of a row of 4 pixels abcd, '.' is the constant color.
+	tests := []struct {
+		name   string
+		border BorderMode
+		want   string
+	}{
+		{"transparent", BORDER_TRANSPARENT, "......abcd......"},
+		{"constant", BorderConstant(color.White), "......abcd......"},
+		{"copy", BORDER_COPY, "aaaaaaabcddddddd"},
+		{"reflect", BORDER_REFLECT, "cddcbaabcddcbaab"},
+		{"reflect101", BORDER_REFLECT101, "abcdcbabcdcbabcd"},
+		{"wrap", BORDER_WRAP, "cdabcdabcdabcdab"},
+	}
+	for _, test := range tests {
+		var got []byte
+		for i := -6; i < 10; i++ {
+			if j, ok := test.border.index(i, 4); ok {
+				got = append(got, byte('a'+j))
+			} else {
+				got = append(got, '.')
+			}
+		}
+		if string(got) != test.want {
+			t.Errorf("%s: %s, want %s", test.name, got, test.want)
+		}
+	}
+	if i, ok := BORDER_REFLECT101.index(-3, 1); !ok || i != 0 {
+		t.Errorf("reflect101 of 1 pixel: %d, %v", i, ok)
+	}
+}
+
+var interpolators = map[string]InterpolationFunc{
+	"Nearest":  Nearest,
+	"Bilinear": Bilinear,
+	"Bicubic":  Bicubic,
+	"Lanczos3": Lanczos3.Interpolate,
+}
+
+// TestBorderWrap translates by whole pixels, which all interpolators do
+// exactly, so the result is the source rolled over.
+func TestBorderWrap(t *testing.T) {
+	src := testPattern()
+	gray := image.NewGray(src.Bounds())
+	draw.Draw(gray, gray.Bounds(), src, image.ZP, draw.Src)
+	nrgba := image.NewNRGBA(src.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), src, image.ZP, draw.Src)
+
+	a := NewAffineMatrix()
+	a.AddTranslation(-10, 7)
+	for _, m := range []image.Image{src, gray, nrgba} {
+		b := m.Bounds()
+		want := newImage(m, b.Dx(), b.Dy())
+		for y := 0; y < b.Dy(); y++ {
+			for x := 0; x < b.Dx(); x++ {
+				want.Set(x, y, m.At(mod(x+10, b.Dx()), mod(y-7, b.Dy())))
+			}
+		}
+		for name, f := range interpolators {
+			got := ApplyBorder(a, m, f, BORDER_WRAP)
+			compareImages(t, name, want, got, 0)
+		}
+	}
+}
+
+func TestBorderConstant(t *testing.T) {
+	src := testPattern()
+	c := color.RGBA{0, 64, 128, 255}
+	// Move the image out of the canvas, only the border is left.
+	a := NewAffineMatrix()
+	a.AddTranslation(100, 0)
+	for name, f := range interpolators {
+		m := ApplyBorder(a, src, f, BorderConstant(c)).(*image.RGBA)
+		for i := 0; i < len(m.Pix); i += 4 {
+			if got := (color.RGBA{m.Pix[i], m.Pix[i+1], m.Pix[i+2], m.Pix[i+3]}); got != c {
+				t.Fatalf("%s: pixel %d = %v, want %v", name, i/4, got, c)
+			}
+		}
+	}
+
+	// Gray images get the gray value of the color.
+	gray := image.NewGray(image.Rect(0, 0, 8, 8))
+	m := ApplyBorder(a, gray, Nearest, BorderConstant(color.White)).(*image.Gray)
+	if m.Pix[0] != 255 {
+		t.Errorf("gray: %d, want 255", m.Pix[0])
+	}
+}
+
+func TestBorderReflect(t *testing.T) {
+	src := testPattern()
+	b := src.Bounds()
+	// Move the image one canvas to the right, only the mirror image is left.
+	a := NewAffineMatrix()
+	a.AddTranslation(float64(b.Dx()), 0)
+	for _, border := range []BorderMode{BORDER_REFLECT, BORDER_REFLECT101} {
+		want := image.NewRGBA(b)
+		for y := 0; y < b.Dy(); y++ {
+			for x := 0; x < b.Dx(); x++ {
+				sx, _ := border.index(x-b.Dx(), b.Dx())
+				want.Set(x, y, src.At(sx, y))
+			}
+		}
+		for name, f := range interpolators {
+			compareImages(t, name, want, ApplyBorder(a, src, f, border), 0)
+		}
+	}
+}
+
+func TestBorderConstantValues(t *testing.T) {
+	// The border modes are constants.
+	const copyMode = BORDER_COPY
+	if copyMode.method() != borderCopy || BORDER_WRAP.method() != borderWrap {
+		t.Error("wrong method")
+	}
+	if BorderConstant(color.Transparent) != BORDER_TRANSPARENT {
+		t.Error("BorderConstant(color.Transparent) != BORDER_TRANSPARENT")
+	}
+	if BorderConstant(color.Black) != BORDER_CONSTANT {
+		t.Error("BorderConstant(color.Black) != BORDER_CONSTANT")
+	}
+	c := color.RGBA64{0x1234, 0x5678, 0x9abc, 0xdef0}
+	if m := BorderConstant(c); m.method() != borderConstant || m.color() != c {
+		t.Errorf("BorderConstant(%v) has method %v and color %v", c, m.method(), m.color())
+	}
+	// A color that is not premultiplied is not mistaken for another mode.
+	if m := BorderConstant(invalidColor{}); m.method() != borderConstant || m.color() != (color.RGBA64{}) {
+		t.Errorf("invalid color has method %v and color %v", m.method(), m.color())
+	}
+}
+
+// invalidColor has red, but no alpha.
+type invalidColor struct{}
+
+func (invalidColor) RGBA() (r, g, b, a uint32) {
+	return 1, 3, 0, 0
+}
@@ -0,0 +1,237 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// CubicKernel is a cubic convolution kernel from the family of Mitchell and
+// Netravali, with parameters B and C. Every kernel of the family reproduces
+// constant colors, and those with B + 2C = 1 also linear gradients.
+//
+//     D. P. Mitchell and A. N. Netravali, 1988.
+//     Reconstruction filters in computer graphics.
+//     Computer Graphics, 22(4), 221-228.
+//
+// The method value k.Interpolate is an InterpolationFunc:
+//     affine.Scale(m, 640, 480, affine.Mitchell.Interpolate)
+type CubicKernel struct {
+	B, C float64
+}
+
+var (
+	// CatmullRom interpolates: it passes through the original pixels and is
+	// the sharpest of the three, with slight ringing at edges.
+	CatmullRom = CubicKernel{0, 0.5}
+
+	// Mitchell is the compromise between blur and ringing recommended by
+	// Mitchell and Netravali.
+	Mitchell = CubicKernel{1.0 / 3, 1.0 / 3}
+
+	// BSpline is the cubic B-spline, which is smooth and never rings, but
+	// blurs the image.
+	BSpline = CubicKernel{1, 0}
+)
+
+// Bicubic performs bicubic interpolation with the CatmullRom kernel.
+func Bicubic(src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	return CatmullRom.Interpolate(src, transform, width, height, border)
+}
+
+// Interpolate performs cubic convolution with kernel k. The kernel is
+// separable, so every destination pixel is computed from 4 rows of 4
+// weighted source pixels.
+func (k CubicKernel) Interpolate(src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	// Fast path for certain image types.
+	switch img := src.(type) {
+	case *image.RGBA:
+		return k.cubicRGBA(img, transform, width, height, border)
+	case *image.Gray:
+		return k.cubicGray(img, transform, width, height, border)
+	}
+	// Standard path
+	dst := newImage(src, width, height)
+	b := src.Bounds()
+	parallel(height, func(y0, y1 int) {
+		for ydst := y0; ydst < y1; ydst++ {
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				x, wx := k.weights(X)
+				y, wy := k.weights(Y)
+				// Are all neighbours outside the source image?
+				if border.transparent() && (x+2 < 0 || y+2 < 0 || x-1 >= b.Dx() || y-1 >= b.Dy()) {
+					continue
+				}
+				// Image boundaries
+				x += b.Min.X
+				y += b.Min.Y
+				var R, G, B, A float64
+				for n := 0; n < 4; n++ {
+					var rr, gg, bb, aa float64
+					for m := 0; m < 4; m++ {
+						r, g, b, a := getColor(src, x-1+m, y-1+n, border).RGBA()
+						rr += wx[m] * float64(r)
+						gg += wx[m] * float64(g)
+						bb += wx[m] * float64(b)
+						aa += wx[m] * float64(a)
+					}
+					R += wy[n] * rr
+					G += wy[n] * gg
+					B += wy[n] * bb
+					A += wy[n] * aa
+				}
+				// Negative lobes can overshoot, colors are premultiplied by alpha.
+				A = clamp(A, 65535)
+				R = clamp(R, A)
+				G = clamp(G, A)
+				B = clamp(B, A)
+				dst.Set(xdst, ydst, color.RGBA64{uint16(R + 0.5), uint16(G + 0.5), uint16(B + 0.5), uint16(A + 0.5)})
+			}
+		}
+	})
+	return dst
+}
+
+func (k CubicKernel) cubicRGBA(src *image.RGBA, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	b := src.Bounds()
+	parallel(height, func(y0, y1 int) {
+		for ydst := y0; ydst < y1; ydst++ {
+			j := ydst * dst.Stride
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				x, wx := k.weights(X)
+				y, wy := k.weights(Y)
+				// Are all neighbours outside the source image?
+				if border.transparent() && (x+2 < 0 || y+2 < 0 || x-1 >= b.Dx() || y-1 >= b.Dy()) {
+					j += 4
+					continue
+				}
+				inside := x >= 1 && y >= 1 && x+2 < b.Dx() && y+2 < b.Dy()
+				// Image boundaries
+				x += b.Min.X
+				y += b.Min.Y
+				var R, G, B, A float64
+				for n := 0; n < 4; n++ {
+					var rr, gg, bb, aa float64
+					if inside {
+						i := (y-1+n-b.Min.Y)*src.Stride + (x-1-b.Min.X)*4
+						p := src.Pix[i : i+16]
+						for m := 0; m < 4; m++ {
+							rr += wx[m] * float64(p[4*m])
+							gg += wx[m] * float64(p[4*m+1])
+							bb += wx[m] * float64(p[4*m+2])
+							aa += wx[m] * float64(p[4*m+3])
+						}
+					} else {
+						for m := 0; m < 4; m++ {
+							r, g, b, a := getRGBA(src, x-1+m, y-1+n, border)
+							rr += wx[m] * float64(r)
+							gg += wx[m] * float64(g)
+							bb += wx[m] * float64(b)
+							aa += wx[m] * float64(a)
+						}
+					}
+					R += wy[n] * rr
+					G += wy[n] * gg
+					B += wy[n] * bb
+					A += wy[n] * aa
+				}
+				A = clamp(A, 255)
+				dst.Pix[j] = uint8(clamp(R, A) + 0.5)
+				dst.Pix[j+1] = uint8(clamp(G, A) + 0.5)
+				dst.Pix[j+2] = uint8(clamp(B, A) + 0.5)
+				dst.Pix[j+3] = uint8(A + 0.5)
+				j += 4
+			}
+		}
+	})
+	return dst
+}
+
+func (k CubicKernel) cubicGray(src *image.Gray, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	b := src.Bounds()
+	parallel(height, func(y0, y1 int) {
+		for ydst := y0; ydst < y1; ydst++ {
+			j := ydst * dst.Stride
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				x, wx := k.weights(X)
+				y, wy := k.weights(Y)
+				// Are all neighbours outside the source image?
+				if border.transparent() && (x+2 < 0 || y+2 < 0 || x-1 >= b.Dx() || y-1 >= b.Dy()) {
+					j++
+					continue
+				}
+				inside := x >= 1 && y >= 1 && x+2 < b.Dx() && y+2 < b.Dy()
+				// Image boundaries
+				x += b.Min.X
+				y += b.Min.Y
+				var G float64
+				for n := 0; n < 4; n++ {
+					var g float64
+					if inside {
+						i := (y-1+n-b.Min.Y)*src.Stride + (x - 1 - b.Min.X)
+						p := src.Pix[i : i+4]
+						g = wx[0]*float64(p[0]) + wx[1]*float64(p[1]) + wx[2]*float64(p[2]) + wx[3]*float64(p[3])
+					} else {
+						for m := 0; m < 4; m++ {
+							g += wx[m] * float64(getGray(src, x-1+m, y-1+n, border))
+						}
+					}
+					G += wy[n] * g
+				}
+				dst.Pix[j] = uint8(clamp(G, 255) + 0.5)
+				j++
+			}
+		}
+	})
+	return dst
+}
+
+// weights returns the column (or row) x of the source pixel left of (or above)
+// source coordinate X, relative to the image bounds, and the weights of the
+// pixels x-1, x, x+1 and x+2.
+func (k CubicKernel) weights(X float64) (int, [4]float64) {
+	// Pixel centers are at +0.5.
+	X -= 0.5
+	f := math.Floor(X)
+	d := X - f
+	w := [4]float64{k.at(1 + d), k.at(d), k.at(1 - d), k.at(2 - d)}
+	// The weights add up to 1, except for rounding errors.
+	s := w[0] + w[1] + w[2] + w[3]
+	for i := range w {
+		w[i] /= s
+	}
+	return int(f), w
+}
+
+// at returns the value of the kernel at distance x.
+func (k CubicKernel) at(x float64) float64 {
+	B, C := k.B, k.C
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return ((12-9*B-6*C)*x*x*x + (-18+12*B+6*C)*x*x + (6 - 2*B)) / 6
+	case x < 2:
+		return ((-B-6*C)*x*x*x + (6*B+30*C)*x*x + (-12*B-48*C)*x + (8*B + 24*C)) / 6
+	}
+	return 0
+}
+
+// clamp returns v limited to [0, max].
+func clamp(v, max float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,216 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+// testPattern returns a 32x24 image with gradients, hard edges and a
+// partially transparent corner.
+func testPattern() *image.RGBA {
+	m := image.NewRGBA(image.Rect(0, 0, 32, 24))
+	for y := 0; y < 24; y++ {
+		for x := 0; x < 32; x++ {
+			c := color.RGBA{uint8(8 * x), uint8(10 * y), 0, 255}
+			if (x/4+y/4)%2 == 0 {
+				c.B = 255
+			}
+			if x < 8 && y < 8 {
+				c = color.RGBA{c.R / 2, c.G / 2, c.B / 2, 128}
+			}
+			m.SetRGBA(x, y, c)
+		}
+	}
+	return m
+}
+
+// checkGolden compares m with testdata/name.png, allowing a difference of 1
+// per channel for rounding differences between architectures.
+func checkGolden(t *testing.T, name string, m image.Image) {
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := png.Encode(f, m); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	golden, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareImages(t, name, golden, m, 257)
+}
+
+// compareImages fails if two images differ by more than tol per 16-bit
+// channel.
+func compareImages(t *testing.T, name string, want, got image.Image, tol uint32) {
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("%s: bounds %v, want %v", name, got.Bounds(), want.Bounds())
+	}
+	b := want.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r0, g0, b0, a0 := want.At(x, y).RGBA()
+			r1, g1, b1, a1 := got.At(x, y).RGBA()
+			if diff(r0, r1) > tol || diff(g0, g1) > tol || diff(b0, b1) > tol || diff(a0, a1) > tol {
+				t.Fatalf("%s: pixel (%d, %d) = %v, want %v", name, x, y, got.At(x, y), want.At(x, y))
+			}
+		}
+	}
+}
+
+func diff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestCubicGolden(t *testing.T) {
+	src := testPattern()
+	for _, k := range []struct {
+		name   string
+		kernel CubicKernel
+	}{
+		{"catmullrom", CatmullRom},
+		{"mitchell", Mitchell},
+		{"bspline", BSpline},
+	} {
+		checkGolden(t, "cubic_"+k.name+"_scale", Scale(src, 53, 37, k.kernel.Interpolate))
+		a := NewAffineMatrix()
+		a.AddRotation(math.Pi/7, 16, 12)
+		checkGolden(t, "cubic_"+k.name+"_rotate", Apply(a, src, k.kernel.Interpolate))
+	}
+}
+
+// TestCubicReference compares the fast paths and the standard path with a
+// direct evaluation of the two-dimensional convolution sum.
+func TestCubicReference(t *testing.T) {
+	src := testPattern()
+	gray := image.NewGray(src.Bounds())
+	draw.Draw(gray, gray.Bounds(), src, image.ZP, draw.Src)
+	nrgba := image.NewNRGBA(src.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), src, image.ZP, draw.Src)
+
+	a := NewAffineMatrix()
+	a.AddRotation(0.3, 16, 12)
+	a.AddZoom(1.7, 1.3, 16, 12)
+	transform := func(x, y int) (float64, float64) {
+		X, Y := float64(x)+0.5, float64(y)+0.5
+		return X*a[0] + Y*a[1] + a[2], X*a[3] + Y*a[4] + a[5]
+	}
+
+	for _, border := range []BorderMode{BORDER_TRANSPARENT, BORDER_COPY} {
+		for _, m := range []image.Image{src, gray, nrgba} {
+			want := referenceCubic(Mitchell, m, transform, 40, 30, border)
+			if m == image.Image(gray) {
+				// Gray has no alpha channel, transparent is black.
+				g := image.NewGray(want.Bounds())
+				draw.Draw(g, g.Bounds(), want, image.ZP, draw.Src)
+				want = g
+			}
+			got := Mitchell.Interpolate(m, transform, 40, 30, border)
+			// The 8 bit paths round once more than the reference, and NRGBA
+			// also rounds when it divides by alpha.
+			compareImages(t, "reference", want, got, 2*257)
+		}
+	}
+}
+
+// referenceCubic evaluates the convolution sum over all source pixels within
+// the support of the kernel.
+func referenceCubic(k CubicKernel, src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	b := src.Bounds()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			X, Y := transform(x, y)
+			var c [4]float64
+			var wsum float64
+			inside := false
+			for sy := int(math.Floor(Y-0.5)) - 1; sy <= int(math.Floor(Y-0.5))+2; sy++ {
+				for sx := int(math.Floor(X-0.5)) - 1; sx <= int(math.Floor(X-0.5))+2; sx++ {
+					w := k.at(X-0.5-float64(sx)) * k.at(Y-0.5-float64(sy))
+					wsum += w
+					if sx >= 0 && sy >= 0 && sx < b.Dx() && sy < b.Dy() {
+						inside = true
+					}
+					px, py := sx, sy
+					if border == BORDER_COPY {
+						px = int(math.Max(0, math.Min(float64(px), float64(b.Dx()-1))))
+						py = int(math.Max(0, math.Min(float64(py), float64(b.Dy()-1))))
+					}
+					if px < 0 || py < 0 || px >= b.Dx() || py >= b.Dy() {
+						continue
+					}
+					r, g, bl, a := src.At(px+b.Min.X, py+b.Min.Y).RGBA()
+					c[0] += w * float64(r)
+					c[1] += w * float64(g)
+					c[2] += w * float64(bl)
+					c[3] += w * float64(a)
+				}
+			}
+			// Pixels without any source neighbours are left transparent,
+			// unless the border has pixels.
+			if !inside && border == BORDER_TRANSPARENT {
+				continue
+			}
+			A := clamp(c[3]/wsum, 65535)
+			dst.SetRGBA64(x, y, color.RGBA64{
+				uint16(clamp(c[0]/wsum, A) + 0.5),
+				uint16(clamp(c[1]/wsum, A) + 0.5),
+				uint16(clamp(c[2]/wsum, A) + 0.5),
+				uint16(A + 0.5),
+			})
+		}
+	}
+	return dst
+}
+
+func TestCubicProperties(t *testing.T) {
+	// Catmull-Rom passes through the original pixels.
+	src := testPattern()
+	same := Scale(src, 32, 24, CatmullRom.Interpolate)
+	compareImages(t, "identity", src, same, 0)
+
+	// Every kernel reproduces a constant color, also at the borders.
+	flat := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.RGBA{10, 100, 200, 255}), image.ZP, draw.Src)
+	for _, k := range []CubicKernel{CatmullRom, Mitchell, BSpline} {
+		m := Scale(flat, 23, 17, k.Interpolate)
+		for y := 0; y < 17; y++ {
+			for x := 0; x < 23; x++ {
+				if c := m.At(x, y); c != (color.RGBA{10, 100, 200, 255}) {
+					t.Fatalf("%v: (%d, %d) = %v", k, x, y, c)
+				}
+			}
+		}
+	}
+
+	// Bicubic is the Catmull-Rom kernel.
+	compareImages(t, "Bicubic", Scale(src, 50, 40, CatmullRom.Interpolate), Scale(src, 50, 40, Bicubic), 0)
+}
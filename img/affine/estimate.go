@@ -0,0 +1,234 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Model is a family of transformations that can be fitted to point
+// correspondences.
+type Model int
+
+const (
+	// RigidModel is rotation and translation, from 2 or more point pairs.
+	RigidModel Model = iota
+	// SimilarityModel is rotation, uniform scaling and translation, from 2
+	// or more point pairs.
+	SimilarityModel
+	// AffineModel is any affine transformation, from 3 or more point pairs
+	// that are not collinear.
+	AffineModel
+)
+
+// minPoints returns the number of point pairs that determine a transformation
+// of model m.
+func (m Model) minPoints() int {
+	if m == AffineModel {
+		return 3
+	}
+	return 2
+}
+
+// EstimateAffine returns the transformation of the given model that maps the
+// points src to the points dst with the least squared error. The result is an
+// AffineMatrix for Apply, so it maps dst back to src.
+//
+// It returns ErrDegenerate if there are too few points, or if they do not
+// determine a transformation.
+//
+// Rigid and similarity transformations are fitted in closed form, as in:
+//     S. Umeyama, 1991.
+//     Least-squares estimation of transformation parameters between two point
+//     patterns. IEEE Trans. Pattern Anal. Mach. Intell., 13(4), 376-380.
+func EstimateAffine(src, dst []Point, model Model) (AffineMatrix, error) {
+	if len(src) != len(dst) {
+		panic("affine.EstimateAffine: number of points does not match.")
+	}
+	f, err := fitForward(src, dst, model)
+	if err != nil {
+		return AffineMatrix{}, err
+	}
+	return f.invertMatrix(), nil
+}
+
+// RANSAC estimates a transformation of the given model like EstimateAffine,
+// from point pairs of which some are mismatched (outliers). Pairs that are
+// mapped to within threshold pixels of each other are inliers.
+//
+// The result is fitted to the inliers of the best of random minimal samples,
+// which are drawn until the best one is found with 99.9% probability, or at
+// most 2000 times. The random samples are the same for every call, so the
+// result is reproducible.
+//
+// For every pair it also returns whether it is an inlier, and its residual:
+// the distance between the transformed src point and the dst point.
+func RANSAC(src, dst []Point, model Model, threshold float64) (a AffineMatrix, inliers []bool, residuals []float64, err error) {
+	if len(src) != len(dst) {
+		panic("affine.RANSAC: number of points does not match.")
+	}
+	n, k := len(src), model.minPoints()
+	if n < k {
+		return AffineMatrix{}, nil, nil, ErrDegenerate
+	}
+	const (
+		confidence = 0.999
+		maxIter    = 2000
+	)
+	rnd := rand.New(rand.NewSource(1))
+	s, d := make([]Point, k), make([]Point, k)
+	residuals = make([]float64, n)
+
+	var best AffineMatrix
+	bestCount, bestSum := 0, math.Inf(1)
+	for iter, need := 0, maxIter; iter < need; iter++ {
+		// k distinct random pairs.
+		for i, j := range rnd.Perm(n)[:k] {
+			s[i], d[i] = src[j], dst[j]
+		}
+		f, err := fitForward(s, d, model)
+		if err != nil {
+			continue
+		}
+		count, sum := 0, 0.0
+		for i := range src {
+			if r := residual(f, src[i], dst[i]); r <= threshold {
+				count++
+				sum += r
+			}
+		}
+		if count == 0 || count < bestCount || count == bestCount && sum >= bestSum {
+			continue
+		}
+		best, bestCount, bestSum = f, count, sum
+		// The number of samples after which one without outliers has been
+		// drawn with the given confidence.
+		w := math.Pow(float64(count)/float64(n), float64(k))
+		if w >= 1 {
+			break
+		}
+		if m := math.Log(1-confidence) / math.Log(1-w); m < float64(need) {
+			need = int(math.Ceil(m))
+		}
+	}
+	if bestCount < k {
+		return AffineMatrix{}, nil, nil, ErrDegenerate
+	}
+
+	// Refit to all inliers, until they no longer change.
+	inliers = make([]bool, n)
+	for i := range src {
+		inliers[i] = residual(best, src[i], dst[i]) <= threshold
+	}
+	for iter := 0; iter < 10; iter++ {
+		s, d = s[:0], d[:0]
+		for i, in := range inliers {
+			if in {
+				s = append(s, src[i])
+				d = append(d, dst[i])
+			}
+		}
+		f, err := fitForward(s, d, model)
+		if err != nil {
+			break
+		}
+		best = f
+		changed := false
+		for i := range src {
+			in := residual(f, src[i], dst[i]) <= threshold
+			changed = changed || in != inliers[i]
+			inliers[i] = in
+		}
+		if !changed {
+			break
+		}
+	}
+	for i := range src {
+		residuals[i] = residual(best, src[i], dst[i])
+		inliers[i] = residuals[i] <= threshold
+	}
+	return best.invertMatrix(), inliers, residuals, nil
+}
+
+// residual returns the distance between point p mapped by the forward matrix
+// f and point q.
+func residual(f AffineMatrix, p, q Point) float64 {
+	r, _ := project(f, p)
+	return math.Hypot(r.X-q.X, r.Y-q.Y)
+}
+
+// fitForward returns the least squares transformation of the given model from
+// src to dst. Unlike an AffineMatrix for Apply, it maps the source to the
+// destination.
+func fitForward(src, dst []Point, model Model) (AffineMatrix, error) {
+	n := len(src)
+	if n < model.minPoints() {
+		return AffineMatrix{}, ErrDegenerate
+	}
+	// Fit to the points relative to their centroids, the translation then
+	// maps centroid to centroid.
+	var ps, pd Point
+	for i := range src {
+		ps.X += src[i].X
+		ps.Y += src[i].Y
+		pd.X += dst[i].X
+		pd.Y += dst[i].Y
+	}
+	ps.X, ps.Y = ps.X/float64(n), ps.Y/float64(n)
+	pd.X, pd.Y = pd.X/float64(n), pd.Y/float64(n)
+	// Second moments of the source, and cross moments:
+	//     [sxx sxy]      [ux uy]
+	//     [sxy syy]  and [vx vy]  with u, v the destination coordinates.
+	var sxx, sxy, syy, ux, uy, vx, vy float64
+	for i := range src {
+		x, y := src[i].X-ps.X, src[i].Y-ps.Y
+		u, v := dst[i].X-pd.X, dst[i].Y-pd.Y
+		sxx += x * x
+		sxy += x * y
+		syy += y * y
+		ux += u * x
+		uy += u * y
+		vx += v * x
+		vy += v * y
+	}
+
+	var a, b, c, d float64 // [a b; c d] maps the source to the destination.
+	switch model {
+	case RigidModel, SimilarityModel:
+		// The rotation θ maximizes Σ (u, v) · R(θ) (x, y).
+		cs, sn := ux+vy, vx-uy
+		norm := math.Hypot(cs, sn)
+		if norm == 0 || sxx+syy == 0 {
+			return AffineMatrix{}, ErrDegenerate
+		}
+		scale := 1.0
+		if model == SimilarityModel {
+			scale = norm / (sxx + syy)
+		}
+		a, b = scale*cs/norm, -scale*sn/norm
+		c, d = -b, a
+	case AffineModel:
+		det := sxx*syy - sxy*sxy
+		if det <= 1e-12*(sxx+syy)*(sxx+syy) {
+			// Collinear points.
+			return AffineMatrix{}, ErrDegenerate
+		}
+		a = (ux*syy - uy*sxy) / det
+		b = (uy*sxx - ux*sxy) / det
+		c = (vx*syy - vy*sxy) / det
+		d = (vy*sxx - vx*sxy) / det
+	default:
+		panic("affine.EstimateAffine: unknown model.")
+	}
+	if a*d-b*c == 0 {
+		return AffineMatrix{}, ErrDegenerate
+	}
+	return AffineMatrix{
+		a, b, pd.X - a*ps.X - b*ps.Y,
+		c, d, pd.Y - c*ps.X - d*ps.Y,
+		0, 0, 1,
+	}, nil
+}
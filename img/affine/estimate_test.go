@@ -0,0 +1,156 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// testPairs returns n random points and their images under the forward
+// transformation of AffineMatrix a.
+func testPairs(a AffineMatrix, n int, rnd *rand.Rand) (src, dst []Point) {
+	for i := 0; i < n; i++ {
+		p := Point{rnd.Float64() * 640, rnd.Float64() * 480}
+		src = append(src, p)
+		dst = append(dst, a.TransformPoint(p))
+	}
+	return src, dst
+}
+
+func TestEstimateAffine(t *testing.T) {
+	rigid := NewAffineMatrix()
+	rigid.AddRotation(0.4, 320, 240)
+	rigid.AddTranslation(12, -7)
+	similarity := rigid
+	similarity.AddZoom(1.3, 1.3, 0, 0)
+	general := similarity
+	general.AddShear(0.1, -0.2, 100, 50)
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name  string
+		a     AffineMatrix
+		model Model
+	}{
+		{"rigid", rigid, RigidModel},
+		{"similarity", similarity, SimilarityModel},
+		{"affine", general, AffineModel},
+		{"affine from rigid", rigid, AffineModel},
+	} {
+		for _, n := range []int{test.model.minPoints(), 20} {
+			src, dst := testPairs(test.a, n, rnd)
+			a, err := EstimateAffine(src, dst, test.model)
+			if err != nil {
+				t.Fatalf("%s from %d points: %v", test.name, n, err)
+			}
+			if !closeMatrix(a, test.a, 1e-9) {
+				t.Errorf("%s from %d points: %v, want %v", test.name, n, a, test.a)
+			}
+		}
+	}
+
+	// A rigid fit to scaled points keeps the scale at 1.
+	src, dst := testPairs(similarity, 20, rnd)
+	a, err := EstimateAffine(src, dst, RigidModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := a.Decompose(); math.Abs(d.Sx-1) > 1e-12 || math.Abs(d.Sy-1) > 1e-12 || math.Abs(d.Rotation-0.4) > 1e-9 {
+		t.Errorf("rigid fit: %+v", d)
+	}
+}
+
+func TestEstimateAffineDegenerate(t *testing.T) {
+	collinear := []Point{{0, 0}, {1, 1}, {2, 2}, {5, 5}}
+	if _, err := EstimateAffine(collinear, collinear, AffineModel); err != ErrDegenerate {
+		t.Errorf("collinear: err = %v, want ErrDegenerate", err)
+	}
+	if _, err := EstimateAffine(collinear[:1], collinear[:1], SimilarityModel); err != ErrDegenerate {
+		t.Errorf("one point: err = %v, want ErrDegenerate", err)
+	}
+	same := []Point{{3, 4}, {3, 4}}
+	if _, err := EstimateAffine(same, same, RigidModel); err != ErrDegenerate {
+		t.Errorf("coincident points: err = %v, want ErrDegenerate", err)
+	}
+}
+
+func TestRANSAC(t *testing.T) {
+	want := NewAffineMatrix()
+	want.AddRotation(-0.2, 320, 240)
+	want.AddZoom(0.9, 1.1, 320, 240)
+	want.AddTranslation(30, 10)
+
+	rnd := rand.New(rand.NewSource(2))
+	src, dst := testPairs(want, 100, rnd)
+	outlier := make([]bool, len(src))
+	for i := range dst {
+		if i%3 == 0 {
+			// Mismatched keypoints.
+			dst[i] = Point{rnd.Float64() * 640, rnd.Float64() * 480}
+			outlier[i] = true
+		} else {
+			dst[i].X += rnd.NormFloat64() * 0.2
+			dst[i].Y += rnd.NormFloat64() * 0.2
+		}
+	}
+
+	a, inliers, residuals, err := RANSAC(src, dst, AffineModel, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range src {
+		if inliers[i] == outlier[i] {
+			t.Errorf("pair %d: inlier %v, residual %v", i, inliers[i], residuals[i])
+		}
+	}
+	if !closeMatrix(a, want, 0.05) {
+		t.Errorf("RANSAC = %v, want %v", a, want)
+	}
+
+	// The least squares fit is pulled away by the outliers.
+	if b, _ := EstimateAffine(src, dst, AffineModel); closeMatrix(b, want, 0.05) {
+		t.Log("least squares is not affected by the outliers, the test is too easy")
+	}
+
+	if _, _, _, err := RANSAC(src[:2], dst[:2], AffineModel, 2); err != ErrDegenerate {
+		t.Errorf("two points: err = %v, want ErrDegenerate", err)
+	}
+}
+
+// TestRANSACOutlierRatio has a few inliers among many outliers, so that most
+// samples fit no pair at all, and those must not end the search.
+func TestRANSACOutlierRatio(t *testing.T) {
+	want := NewAffineMatrix()
+	want.AddRotation(0.3, 320, 240)
+	want.AddTranslation(-20, 15)
+
+	for layout := 0; layout < 4; layout++ {
+		rnd := rand.New(rand.NewSource(int64(10 + layout)))
+		src, dst := testPairs(want, 20, rnd)
+		inlier := make([]bool, len(src))
+		for i := range dst {
+			if (i+layout)%4 == 0 {
+				inlier[i] = true
+				continue
+			}
+			dst[i] = Point{rnd.Float64() * 640, rnd.Float64() * 480}
+		}
+
+		a, inliers, _, err := RANSAC(src, dst, RigidModel, 1)
+		if err != nil {
+			t.Fatalf("layout %d: %v", layout, err)
+		}
+		for i := range src {
+			if inliers[i] != inlier[i] {
+				t.Errorf("layout %d: pair %d: inlier %v, want %v", layout, i, inliers[i], inlier[i])
+			}
+		}
+		if !closeMatrix(a, want, 1e-6) {
+			t.Errorf("layout %d: RANSAC = %v, want %v", layout, a, want)
+		}
+	}
+}
@@ -5,11 +5,14 @@
 package affine_test
 
 import (
-	"affine"
+	"image"
 	"image/png"
 	"log"
 	"math"
 	"os"
+	"testing"
+
+	"github.com/harrydb/go/img/affine"
 )
 
 // Single affine transform.
@@ -20,7 +23,7 @@ func Example() {
 	}
 
 	log.Println("Decoding...")
-	img, err := pnm.Decode(file)
+	img, err := png.Decode(file)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -45,22 +48,22 @@ func Example() {
 // By multiplying affine transformation matrices, more than one transformation
 // can be performed at once. Convenience functions for this are used in the
 // example below.
-func Example() {
+func Example_chain() {
 	file, err := os.Open("in.png")
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	log.Println("Decoding...")
-	img, err := pnm.Decode(file)
+	img, err := png.Decode(file)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Create new affine transformation matrix.
 	t := affine.NewAffineMatrix()
-	xcenter := float64(outImg.Bounds().Dx()) / 2
-	ycenter := float64(outImg.Bounds().Dy()) / 2
+	xcenter := float64(img.Bounds().Dx()) / 2
+	ycenter := float64(img.Bounds().Dy()) / 2
 
 	// Modify the transformation matrix:
 	t.AddZoom(0.25, 0.25, xcenter/2, ycenter)
@@ -68,7 +71,7 @@ func Example() {
 
 	// Apply the transformation.
 	log.Println("Transforming...")
-	outImg = affine.Apply(t, outImg, affine.Bilinear)
+	outImg := affine.Apply(t, img, affine.Bilinear)
 
 	log.Println("Writing...")
 	outFile, err := os.Create("out.png")
@@ -83,3 +86,63 @@ func Example() {
 	log.Println("Done...")
 
 }
+
+func benchmarkRotate(b *testing.B, workers int, interpolate affine.InterpolationFunc) {
+	img := image.NewRGBA(image.Rect(0, 0, 1024, 768))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+	benchmarkRotateImage(b, img, workers, interpolate)
+}
+
+func benchmarkRotateImage(b *testing.B, img image.Image, workers int, interpolate affine.InterpolationFunc) {
+	a := affine.NewAffineMatrix()
+	a.AddRotation(math.Pi/6, 512, 384)
+	affine.SetWorkers(workers)
+	defer affine.SetWorkers(0)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		affine.Apply(a, img, interpolate)
+	}
+}
+
+func BenchmarkRotateBilinearSerial(b *testing.B) { benchmarkRotate(b, 1, affine.Bilinear) }
+func BenchmarkRotateBilinear(b *testing.B)       { benchmarkRotate(b, 0, affine.Bilinear) }
+func BenchmarkRotateBicubicSerial(b *testing.B)  { benchmarkRotate(b, 1, affine.Bicubic) }
+func BenchmarkRotateBicubic(b *testing.B)        { benchmarkRotate(b, 0, affine.Bicubic) }
+func BenchmarkRotateNearestSerial(b *testing.B)  { benchmarkRotate(b, 1, affine.Nearest) }
+func BenchmarkRotateNearest(b *testing.B)        { benchmarkRotate(b, 0, affine.Nearest) }
+
+// benchmarkRotateYCbCr rotates an image as decoded from a JPEG.
+func benchmarkRotateYCbCr(b *testing.B, interpolate affine.InterpolationFunc) {
+	img := image.NewYCbCr(image.Rect(0, 0, 1024, 768), image.YCbCrSubsampleRatio420)
+	for i := range img.Y {
+		img.Y[i] = uint8(i * 7)
+	}
+	for i := range img.Cb {
+		img.Cb[i] = uint8(i * 3)
+		img.Cr[i] = uint8(i * 5)
+	}
+	benchmarkRotateImage(b, img, 1, interpolate)
+}
+
+func BenchmarkRotateBilinearYCbCr(b *testing.B) { benchmarkRotateYCbCr(b, affine.Bilinear) }
+func BenchmarkRotateNearestYCbCr(b *testing.B)  { benchmarkRotateYCbCr(b, affine.Nearest) }
+
+func benchmarkScale(b *testing.B, workers int, interpolate affine.InterpolationFunc) {
+	img := image.NewRGBA(image.Rect(0, 0, 1024, 768))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+	affine.SetWorkers(workers)
+	defer affine.SetWorkers(0)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		affine.Scale(img, 256, 192, interpolate)
+	}
+}
+
+func BenchmarkScaleLanczos3Serial(b *testing.B) { benchmarkScale(b, 1, affine.Lanczos3.Interpolate) }
+func BenchmarkScaleLanczos3(b *testing.B)       { benchmarkScale(b, 0, affine.Lanczos3.Interpolate) }
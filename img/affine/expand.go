@@ -0,0 +1,58 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+)
+
+// ApplyExpand is Apply, but the canvas is resized to fit the whole
+// transformed image, so nothing is cropped.
+//
+// The returned offset is the position of the top left corner of the new
+// canvas on the canvas that Apply would use: pixel (x, y) of the result is
+// pixel (x + offset.X, y + offset.Y) of Apply(a, src, interpolate), had that
+// canvas been large enough.
+//
+// ApplyExpand panics if a is singular, which a.Inverse reports with
+// ErrSingular, or if a is projective and maps a corner of src to infinity or
+// beyond, as the canvas would be infinite.
+func ApplyExpand(a AffineMatrix, src image.Image, interpolate InterpolationFunc) (m image.Image, offset image.Point) {
+	if a.det() == 0 {
+		panic("affine.ApplyExpand: matrix is singular.")
+	}
+	// a maps the destination to the source, its inverse maps the corners of
+	// the source to the destination.
+	r, ok := boundingBox(a.invertMatrix(), image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	if !ok {
+		panic("affine.ApplyExpand: image is mapped to infinity.")
+	}
+	offset = r.Min
+	width, height := r.Dx(), r.Dy()
+	return interpolate(src, a.transform(offset.X, offset.Y), width, height, BORDER_TRANSPARENT), offset
+}
+
+// RotateExpand returns image m rotated around the center by θ radians, on a
+// canvas that fits the whole rotated image. The offset is as for ApplyExpand.
+//
+// The interpolation function used is transform.Bilinear.
+func RotateExpand(m image.Image, θ float64) (image.Image, image.Point) {
+	b := m.Bounds()
+	xcenter := float64(b.Dx()) / 2
+	ycenter := float64(b.Dy()) / 2
+	return ApplyExpand(rotationMatrix(θ, xcenter, ycenter), m, Bilinear)
+}
+
+// ShearExpand returns image m sheared horizontally by hx and vertically by
+// hy, on a canvas that fits the whole sheared image. The offset is as for
+// ApplyExpand.
+//
+// The interpolation function used is transform.Bilinear.
+func ShearExpand(m image.Image, hx, hy float64) (image.Image, image.Point) {
+	b := m.Bounds()
+	xcenter := float64(b.Dx()) / 2
+	ycenter := float64(b.Dy()) / 2
+	return ApplyExpand(shearMatrix(hx, hy, xcenter, ycenter), m, Bilinear)
+}
@@ -0,0 +1,80 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+)
+
+func TestApplyExpandIdentity(t *testing.T) {
+	src := testPattern()
+	m, off := ApplyExpand(NewAffineMatrix(), src, Nearest)
+	if off != (image.Point{}) {
+		t.Errorf("offset = %v, want (0, 0)", off)
+	}
+	compareImages(t, "identity", src, m, 0)
+}
+
+func TestApplyExpandTranslation(t *testing.T) {
+	src := testPattern()
+	a := NewAffineMatrix()
+	a.AddTranslation(10, 5)
+	m, off := ApplyExpand(a, src, Nearest)
+	if off != image.Pt(10, 5) {
+		t.Errorf("offset = %v, want (10, 5)", off)
+	}
+	// Nothing is cropped, so the content is the source itself.
+	compareImages(t, "translation", src, m, 0)
+}
+
+func TestRotateExpand(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.ZP, draw.Src)
+
+	m, off := RotateExpand(src, math.Pi/2)
+	if b := m.Bounds(); b.Dx() != 20 || b.Dy() != 40 {
+		t.Errorf("90°: size = %v, want 20x40", b.Size())
+	}
+	if off != image.Pt(10, -10) {
+		t.Errorf("90°: offset = %v, want (10, -10)", off)
+	}
+
+	// After rotating by 45° the corners touch the canvas and no opaque pixel
+	// is lost.
+	m, off = RotateExpand(src, math.Pi/4)
+	b := m.Bounds()
+	side := 60 / math.Sqrt2
+	if w := int(math.Ceil(20+side/2)) - int(math.Floor(20-side/2)); b.Dx() != w || b.Dy() != w {
+		t.Errorf("45°: size = %v, want %dx%d", b.Size(), w, w)
+	}
+	var sum float64
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			_, _, _, a := m.At(x, y).RGBA()
+			sum += float64(a) / 0xffff
+		}
+	}
+	// Bilinear blends the edges with the transparent border, which loses a
+	// little alpha along the 120 pixel perimeter.
+	if math.Abs(sum-40*20) > 6 {
+		t.Errorf("45°: total alpha = %v, want %v", sum, 40*20)
+	}
+}
+
+func TestShearExpand(t *testing.T) {
+	src := testPattern()
+	m, off := ShearExpand(src, 0.5, 0)
+	// The rows are shifted by up to ±6 pixels.
+	if b := m.Bounds(); b.Dx() != 32+12 || b.Dy() != 24 {
+		t.Errorf("size = %v, want 44x24", b.Size())
+	}
+	if off != image.Pt(-6, 0) {
+		t.Errorf("offset = %v, want (-6, 0)", off)
+	}
+}
@@ -0,0 +1,378 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// pixels returns the pixel data of m, with its stride and the number of bytes
+// per pixel, for the image types that store all channels in Pix.
+func pixels(m image.Image) (pix []uint8, stride, bpp int, ok bool) {
+	switch img := m.(type) {
+	case *image.RGBA:
+		return img.Pix, img.Stride, 4, true
+	case *image.NRGBA:
+		return img.Pix, img.Stride, 4, true
+	case *image.RGBA64:
+		return img.Pix, img.Stride, 8, true
+	case *image.NRGBA64:
+		return img.Pix, img.Stride, 8, true
+	case *image.Gray:
+		return img.Pix, img.Stride, 1, true
+	case *image.Gray16:
+		return img.Pix, img.Stride, 2, true
+	case *image.Alpha:
+		return img.Pix, img.Stride, 1, true
+	case *image.Alpha16:
+		return img.Pix, img.Stride, 2, true
+	}
+	return nil, 0, 0, false
+}
+
+// borderPix returns the color of a constant border in the pixel format of m.
+func borderPix(m draw.Image, border BorderMode) []uint8 {
+	one := newImage(m, 1, 1)
+	one.Set(0, 0, border.color())
+	pix, _, _, _ := pixels(one)
+	return pix
+}
+
+// pixOffset returns the offset in Pix of pixel (x, y), relative to bounds b,
+// or false if it has the color of a constant border.
+func pixOffset(x, y int, b image.Rectangle, stride, bpp int, border BorderMode) (int, bool) {
+	x, okx := border.index(x, b.Dx())
+	y, oky := border.index(y, b.Dy())
+	if !okx || !oky {
+		return 0, false
+	}
+	return y*stride + x*bpp, true
+}
+
+// nearestPix is Nearest for the image types of pixels, it copies the bytes of
+// the source pixels.
+func nearestPix(src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := newImage(src, width, height)
+	spix, sstride, bpp, _ := pixels(src)
+	dpix, dstride, _, _ := pixels(dst)
+	bc := borderPix(dst, border)
+	b := src.Bounds()
+	parallel(height, func(y0, y1 int) {
+		for ydst := y0; ydst < y1; ydst++ {
+			j := ydst * dstride
+			for xdst := 0; xdst < width; xdst, j = xdst+1, j+bpp {
+				X, Y := transform(xdst, ydst)
+				x := int(X)
+				y := int(Y)
+				if X < 0.0 {
+					x -= 1
+				}
+				if Y < 0.0 {
+					y -= 1
+				}
+				// Is this pixel outside the source image?
+				if border.transparent() && (x < 0 || y < 0 || x >= b.Dx() || y >= b.Dy()) {
+					continue
+				}
+				p := bc
+				if i, ok := pixOffset(x, y, b, sstride, bpp, border); ok {
+					p = spix[i : i+bpp]
+				}
+				copy(dpix[j:j+bpp], p)
+			}
+		}
+	})
+	return dst
+}
+
+// bilinearPix calls f for every destination pixel of Bilinear, with the
+// column x and row y of the top left of the four source pixels, relative to
+// bounds b, and the weights of the top left, top right, bottom left and
+// bottom right pixels.
+func bilinearPix(b image.Rectangle, transform TransformFunc, width, height int, border BorderMode, f func(xdst, ydst, x, y int, w *[4]float64)) {
+	parallel(height, func(y0, y1 int) {
+		var w [4]float64
+		for ydst := y0; ydst < y1; ydst++ {
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				X -= 0.5
+				Y -= 0.5
+				x := int(X)
+				y := int(Y)
+				if X < 0.0 {
+					x -= 1
+				}
+				if Y < 0.0 {
+					y -= 1
+				}
+				// Are all neighbours outside the source image?
+				if border.transparent() && (x < -1 || y < -1 || x >= b.Dx() || y >= b.Dy()) {
+					continue
+				}
+				// Pixel weights
+				dx := X - float64(x)
+				dy := Y - float64(y)
+				w = [4]float64{(1 - dx) * (1 - dy), dx * (1 - dy), (1 - dx) * dy, dx * dy}
+				f(xdst, ydst, x, y, &w)
+			}
+		}
+	})
+}
+
+// u16 returns the big endian 16 bit value of p.
+func u16(p []uint8) float64 {
+	return float64(uint16(p[0])<<8 | uint16(p[1]))
+}
+
+// put16 stores v as a big endian 16 bit value in p.
+func put16(p []uint8, v float64) {
+	p[0] = uint8(uint16(v) >> 8)
+	p[1] = uint8(uint16(v))
+}
+
+func bilinearNRGBA(src *image.NRGBA, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	bc := borderPix(dst, border)
+	b := src.Bounds()
+	bilinearPix(b, transform, width, height, border, func(xdst, ydst, x, y int, w *[4]float64) {
+		// Interpolate the colors premultiplied by alpha.
+		var R, G, B, A float64
+		for n, wn := range w {
+			p := bc
+			if i, ok := pixOffset(x+n%2, y+n/2, b, src.Stride, 4, border); ok {
+				p = src.Pix[i : i+4]
+			}
+			a := wn * float64(p[3])
+			R += a * float64(p[0])
+			G += a * float64(p[1])
+			B += a * float64(p[2])
+			A += a
+		}
+		if A == 0 {
+			return
+		}
+		j := ydst*dst.Stride + 4*xdst
+		dst.Pix[j] = uint8(R/A + 0.5)
+		dst.Pix[j+1] = uint8(G/A + 0.5)
+		dst.Pix[j+2] = uint8(B/A + 0.5)
+		dst.Pix[j+3] = uint8(A + 0.5)
+	})
+	return dst
+}
+
+func bilinearNRGBA64(src *image.NRGBA64, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewNRGBA64(image.Rect(0, 0, width, height))
+	bc := borderPix(dst, border)
+	b := src.Bounds()
+	bilinearPix(b, transform, width, height, border, func(xdst, ydst, x, y int, w *[4]float64) {
+		// Interpolate the colors premultiplied by alpha.
+		var R, G, B, A float64
+		for n, wn := range w {
+			p := bc
+			if i, ok := pixOffset(x+n%2, y+n/2, b, src.Stride, 8, border); ok {
+				p = src.Pix[i : i+8]
+			}
+			a := wn * u16(p[6:])
+			R += a * u16(p[0:])
+			G += a * u16(p[2:])
+			B += a * u16(p[4:])
+			A += a
+		}
+		if A == 0 {
+			return
+		}
+		j := ydst*dst.Stride + 8*xdst
+		put16(dst.Pix[j:], R/A+0.5)
+		put16(dst.Pix[j+2:], G/A+0.5)
+		put16(dst.Pix[j+4:], B/A+0.5)
+		put16(dst.Pix[j+6:], A+0.5)
+	})
+	return dst
+}
+
+func bilinearRGBA64(src *image.RGBA64, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	bc := borderPix(dst, border)
+	b := src.Bounds()
+	bilinearPix(b, transform, width, height, border, func(xdst, ydst, x, y int, w *[4]float64) {
+		// Calculate new color, add 0.5 to round to nearest integer.
+		var R, G, B, A float64 = 0.5, 0.5, 0.5, 0.5
+		for n, wn := range w {
+			p := bc
+			if i, ok := pixOffset(x+n%2, y+n/2, b, src.Stride, 8, border); ok {
+				p = src.Pix[i : i+8]
+			}
+			R += wn * u16(p[0:])
+			G += wn * u16(p[2:])
+			B += wn * u16(p[4:])
+			A += wn * u16(p[6:])
+		}
+		j := ydst*dst.Stride + 8*xdst
+		put16(dst.Pix[j:], R)
+		put16(dst.Pix[j+2:], G)
+		put16(dst.Pix[j+4:], B)
+		put16(dst.Pix[j+6:], A)
+	})
+	return dst
+}
+
+func bilinearGray16(src *image.Gray16, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	dst := image.NewGray16(image.Rect(0, 0, width, height))
+	bc := borderPix(dst, border)
+	b := src.Bounds()
+	bilinearPix(b, transform, width, height, border, func(xdst, ydst, x, y int, w *[4]float64) {
+		// Calculate new color, add 0.5 to round to nearest integer.
+		var G float64 = 0.5
+		for n, wn := range w {
+			p := bc
+			if i, ok := pixOffset(x+n%2, y+n/2, b, src.Stride, 2, border); ok {
+				p = src.Pix[i : i+2]
+			}
+			G += wn * u16(p)
+		}
+		put16(dst.Pix[ydst*dst.Stride+2*xdst:], G)
+	})
+	return dst
+}
+
+// plane is the luma or a chroma plane of an *image.YCbCr.
+type plane struct {
+	pix    []uint8
+	stride int
+	w, h   int
+}
+
+// at returns sample (x, y) of p, or c beyond the border.
+func (p plane) at(x, y int, border BorderMode, c uint8) float64 {
+	x, okx := border.index(x, p.w)
+	y, oky := border.index(y, p.h)
+	if !okx || !oky {
+		return float64(c)
+	}
+	return float64(p.pix[y*p.stride+x])
+}
+
+// nearest returns the sample of p at coordinate (X, Y), or false if the
+// destination sample is to be left as it is.
+func (p plane) nearest(X, Y float64, border BorderMode, c uint8) (uint8, bool) {
+	x := int(X)
+	y := int(Y)
+	if X < 0.0 {
+		x -= 1
+	}
+	if Y < 0.0 {
+		y -= 1
+	}
+	// Is this sample outside the plane?
+	if border.transparent() && (x < 0 || y < 0 || x >= p.w || y >= p.h) {
+		return 0, false
+	}
+	return uint8(p.at(x, y, border, c)), true
+}
+
+// bilinear returns the bilinear interpolation of p at coordinate (X, Y), or
+// false if the destination sample is to be left as it is.
+func (p plane) bilinear(X, Y float64, border BorderMode, c uint8) (uint8, bool) {
+	X -= 0.5
+	Y -= 0.5
+	x := int(X)
+	y := int(Y)
+	if X < 0.0 {
+		x -= 1
+	}
+	if Y < 0.0 {
+		y -= 1
+	}
+	// Are all neighbours outside the plane?
+	if border.transparent() && (x < -1 || y < -1 || x >= p.w || y >= p.h) {
+		return 0, false
+	}
+	dx := X - float64(x)
+	dy := Y - float64(y)
+	// Add 0.5 to round to nearest integer.
+	v := 0.5 + (1-dy)*((1-dx)*p.at(x, y, border, c)+dx*p.at(x+1, y, border, c)) +
+		dy*((1-dx)*p.at(x, y+1, border, c)+dx*p.at(x+1, y+1, border, c))
+	return uint8(v), true
+}
+
+// subsampling returns the number of pixels per chroma sample horizontally and
+// vertically.
+func subsampling(r image.YCbCrSubsampleRatio) (sx, sy int) {
+	switch r {
+	case image.YCbCrSubsampleRatio422:
+		return 2, 1
+	case image.YCbCrSubsampleRatio420:
+		return 2, 2
+	case image.YCbCrSubsampleRatio440:
+		return 1, 2
+	case image.YCbCrSubsampleRatio411:
+		return 4, 1
+	case image.YCbCrSubsampleRatio410:
+		return 4, 2
+	}
+	return 1, 1
+}
+
+// resampleYCbCr is Nearest or Bilinear for *image.YCbCr, depending on sample.
+// The luma is resampled for every destination pixel, the chroma for every
+// destination chroma sample, at the mean source coordinate of its pixels.
+func resampleYCbCr(src *image.YCbCr, transform TransformFunc, width, height int, border BorderMode, sample func(p plane, X, Y float64, border BorderMode, c uint8) (uint8, bool)) image.Image {
+	dst := image.NewYCbCr(image.Rect(0, 0, width, height), src.SubsampleRatio)
+	// Transparent is black, as for gray images.
+	for i := range dst.Cb {
+		dst.Cb[i] = 128
+		dst.Cr[i] = 128
+	}
+	c := color.YCbCrModel.Convert(border.color()).(color.YCbCr)
+	b := src.Bounds()
+
+	luma := plane{src.Y, src.YStride, b.Dx(), b.Dy()}
+	parallel(height, func(y0, y1 int) {
+		for ydst := y0; ydst < y1; ydst++ {
+			j := ydst * dst.YStride
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				if v, ok := sample(luma, X, Y, border, c.Y); ok {
+					dst.Y[j+xdst] = v
+				}
+			}
+		}
+	})
+
+	// Chroma sample i covers the pixels [i * sx, (i + 1) * sx), also in the
+	// source, where the first sample is the one of b.Min.
+	sx, sy := subsampling(src.SubsampleRatio)
+	cx0, cy0 := b.Min.X/sx, b.Min.Y/sy
+	cb := plane{src.Cb, src.CStride, (b.Max.X+sx-1)/sx - cx0, (b.Max.Y+sy-1)/sy - cy0}
+	cr := plane{src.Cr, src.CStride, cb.w, cb.h}
+	parallel((height+sy-1)/sy, func(y0, y1 int) {
+		for cy := y0; cy < y1; cy++ {
+			j := cy * dst.CStride
+			for cx := 0; cx < (width+sx-1)/sx; cx++ {
+				var X, Y float64
+				n := 0
+				for y := cy * sy; y < cy*sy+sy && y < height; y++ {
+					for x := cx * sx; x < cx*sx+sx && x < width; x++ {
+						Xp, Yp := transform(x, y)
+						X += Xp
+						Y += Yp
+						n++
+					}
+				}
+				X = (X/float64(n)+float64(b.Min.X))/float64(sx) - float64(cx0)
+				Y = (Y/float64(n)+float64(b.Min.Y))/float64(sy) - float64(cy0)
+				if v, ok := sample(cb, X, Y, border, c.Cb); ok {
+					dst.Cb[j+cx] = v
+				}
+				if v, ok := sample(cr, X, Y, border, c.Cr); ok {
+					dst.Cr[j+cx] = v
+				}
+			}
+		}
+	})
+	return dst
+}
@@ -0,0 +1,125 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"testing"
+)
+
+// generic hides the type of an image, so that the standard path is used.
+type generic struct {
+	image.Image
+}
+
+// convert returns m drawn onto a new image of the same type as to.
+func convert(m image.Image, to draw.Image) draw.Image {
+	draw.Draw(to, to.Bounds(), m, m.Bounds().Min, draw.Src)
+	return to
+}
+
+func TestFastPaths(t *testing.T) {
+	src := testPattern()
+	r := src.Bounds()
+	sources := []image.Image{
+		convert(src, image.NewRGBA(r)),
+		convert(src, image.NewNRGBA(r)),
+		convert(src, image.NewRGBA64(r)),
+		convert(src, image.NewNRGBA64(r)),
+		convert(src, image.NewGray(r)),
+		convert(src, image.NewGray16(r)),
+		convert(src, image.NewAlpha(r)),
+		convert(src, image.NewAlpha16(r)),
+	}
+	a := NewAffineMatrix()
+	a.AddRotation(0.3, 16, 12)
+	a.AddZoom(1.4, 1.2, 16, 12)
+	a.AddTranslation(3, -2)
+	transform := a.transform(-4, -3)
+	borders := []BorderMode{BORDER_TRANSPARENT, BORDER_COPY, BORDER_WRAP, BorderConstant(color.RGBA{10, 200, 30, 255})}
+
+	for _, m := range sources {
+		for _, border := range borders {
+			for name, f := range map[string]InterpolationFunc{"Nearest": Nearest, "Bilinear": Bilinear} {
+				got := f(m, transform, 40, 30, border)
+				if reflect.TypeOf(got) != reflect.TypeOf(m) {
+					t.Errorf("%s %T: result is %T", name, m, got)
+				}
+				// The standard path writes an RGBA image, which rounds
+				// premultiplied colors to 8 bits, and NRGBA also rounds
+				// when it divides by alpha.
+				want := f(generic{m}, transform, 40, 30, border)
+				switch m.(type) {
+				case *image.Gray, *image.Gray16, *image.Alpha, *image.Alpha16:
+					// Gray has no alpha channel, transparent is black, and
+					// Alpha has only alpha.
+					want = convert(want, newImage(m, 40, 30))
+				}
+				compareImages(t, name, want, got, 2*257)
+			}
+		}
+	}
+}
+
+func TestFastPathYCbCr(t *testing.T) {
+	src := testPattern()
+	// An opaque source, as a decoded JPEG.
+	opaque := image.NewRGBA(src.Bounds())
+	draw.Draw(opaque, opaque.Bounds(), image.NewUniform(color.White), image.ZP, draw.Src)
+	draw.Draw(opaque, opaque.Bounds(), src, image.ZP, draw.Over)
+	ycbcr := func(r image.YCbCrSubsampleRatio) *image.YCbCr {
+		m := image.NewYCbCr(opaque.Bounds(), r)
+		b := m.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := opaque.RGBAAt(x, y)
+				yy, cb, cr := color.RGBToYCbCr(c.R, c.G, c.B)
+				m.Y[m.YOffset(x, y)] = yy
+				// The last pixel of a chroma sample sets it.
+				m.Cb[m.COffset(x, y)] = cb
+				m.Cr[m.COffset(x, y)] = cr
+			}
+		}
+		return m
+	}
+
+	for _, f := range []InterpolationFunc{Nearest, Bilinear} {
+		// The identity keeps all samples.
+		for _, r := range []image.YCbCrSubsampleRatio{image.YCbCrSubsampleRatio444, image.YCbCrSubsampleRatio422, image.YCbCrSubsampleRatio420} {
+			m := ycbcr(r)
+			got, ok := f(m, NewAffineMatrix().transform(0, 0), 32, 24, BORDER_TRANSPARENT).(*image.YCbCr)
+			if !ok {
+				t.Fatalf("result is not *image.YCbCr")
+			}
+			if got.SubsampleRatio != r || !reflect.DeepEqual(got.Y, m.Y) || !reflect.DeepEqual(got.Cb, m.Cb) || !reflect.DeepEqual(got.Cr, m.Cr) {
+				t.Errorf("%v: identity changes the image", r)
+			}
+		}
+
+		// Without subsampling, the result is close to interpolating RGB.
+		m := ycbcr(image.YCbCrSubsampleRatio444)
+		a := NewAffineMatrix()
+		a.AddRotation(0.3, 16, 12)
+		// (YCbCr has no transparent pixels.)
+		for _, border := range []BorderMode{BORDER_COPY, BORDER_REFLECT} {
+			got := f(m, a.transform(0, 0), 32, 24, border)
+			want := f(generic{m}, a.transform(0, 0), 32, 24, border)
+			// Rounding of the conversions between RGB and YCbCr.
+			compareImages(t, "YCbCr", want, got, 3*257)
+		}
+	}
+
+	// Transparent is black.
+	m := ycbcr(image.YCbCrSubsampleRatio420)
+	a := NewAffineMatrix()
+	a.AddTranslation(100, 0)
+	got := Bilinear(m, a.transform(0, 0), 8, 8, BORDER_TRANSPARENT)
+	if r, g, b, _ := got.At(3, 3).RGBA(); r != 0 || g != 0 || b != 0 {
+		t.Errorf("transparent: %v", got.At(3, 3))
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync/atomic"
+)
+
+// Filter is a resampling filter. Unlike Nearest, Bilinear and Bicubic, which
+// always use the same number of source pixels, a Filter widens its support by
+// the scale factor when the image is made smaller, so every source pixel
+// contributes and fine detail does not alias.
+//
+// The method value f.Interpolate is an InterpolationFunc:
+//     thumb := affine.Scale(m, 160, 120, affine.Lanczos3.Interpolate)
+type Filter struct {
+	// Support is the radius of the kernel, in pixels, when the image is not
+	// made smaller.
+	Support float64
+
+	// Kernel returns the weight of a pixel at distance x. It is zero for
+	// |x| >= Support.
+	Kernel func(x float64) float64
+}
+
+var (
+	// Box averages the pixels that are covered by the destination pixel.
+	// When enlarging it is nearest neighbor interpolation.
+	Box = Filter{0.5, func(x float64) float64 {
+		if x >= -0.5 && x < 0.5 {
+			return 1
+		}
+		return 0
+	}}
+
+	// Triangle is bilinear interpolation, widened when reducing.
+	Triangle = Filter{1, func(x float64) float64 {
+		x = math.Abs(x)
+		if x < 1 {
+			return 1 - x
+		}
+		return 0
+	}}
+
+	// Lanczos2 is the Lanczos filter with two lobes.
+	Lanczos2 = Filter{2, func(x float64) float64 {
+		return lanczos(x, 2)
+	}}
+
+	// Lanczos3 is the Lanczos filter with three lobes, which keeps the image
+	// sharpest, at the cost of some ringing.
+	Lanczos3 = Filter{3, func(x float64) float64 {
+		return lanczos(x, 3)
+	}}
+
+	// Gaussian is a Gaussian filter with standard deviation 0.5, which
+	// blurs slightly but never rings.
+	Gaussian = Filter{2, func(x float64) float64 {
+		if math.Abs(x) < 2 {
+			return math.Exp(-2 * x * x)
+		}
+		return 0
+	}}
+)
+
+func lanczos(x, a float64) float64 {
+	x = math.Abs(x)
+	switch {
+	case x == 0:
+		return 1
+	case x < a:
+		πx := math.Pi * x
+		return a * math.Sin(πx) * math.Sin(πx/a) / (πx * πx)
+	}
+	return 0
+}
+
+// Interpolate resamples src with filter f.
+//
+// If transform only scales and translates, as for Scale and ScaleFactor, the
+// image is resampled in two passes, first the rows and then the columns. This
+// is checked at every destination pixel. Other transformations are resampled
+// in one pass, with the support widened by the local scale factor, at most
+// maxFilterScale.
+func (f Filter) Interpolate(src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	if X0, sx, Y0, sy, ok := axisAligned(transform, width, height); ok {
+		return f.separable(src, X0, sx, Y0, sy, width, height, border)
+	}
+	return f.general(src, transform, width, height, border)
+}
+
+// maxFilterScale caps the widening of the support in one pass. Near the
+// horizon of a projective transformation, for example, the footprint of a
+// destination pixel grows without bound, while the work per pixel grows with
+// its area. Such reductions alias a little, scaling has no cap.
+const maxFilterScale = 16
+
+// axisAligned reports whether transform is X = X0 + x * sx, Y = Y0 + y * sy
+// at every destination pixel (x, y).
+func axisAligned(transform TransformFunc, width, height int) (X0, sx, Y0, sy float64, ok bool) {
+	X0, Y0 = transform(0, 0)
+	X1, _ := transform(1, 0)
+	_, Y1 := transform(0, 1)
+	sx, sy = X1-X0, Y1-Y0
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-9*(1+math.Abs(a)+math.Abs(b))
+	}
+	if !near(X0+sx, X1) || !near(Y0+sy, Y1) {
+		// NaN or infinite.
+		return 0, 0, 0, 0, false
+	}
+	var failed int32
+	parallel(height, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			if atomic.LoadInt32(&failed) != 0 {
+				return
+			}
+			for x := 0; x < width; x++ {
+				X, Y := transform(x, y)
+				if !near(X, X0+float64(x)*sx) || !near(Y, Y0+float64(y)*sy) {
+					atomic.StoreInt32(&failed, 1)
+					return
+				}
+			}
+		}
+	})
+	return X0, sx, Y0, sy, failed == 0
+}
+
+// contrib lists the source pixels and weights of one destination row or
+// column. An empty contrib has no source pixels within the support, and index
+// -1 stands for the color of a constant border.
+type contrib struct {
+	index  []int
+	weight []float64
+}
+
+// contribs returns the contributions to n destination pixels with centers at
+// source coordinates c0 + i * s, from a source of size m.
+func (f Filter) contribs(n, m int, c0, s float64, border BorderMode) []contrib {
+	cs := make([]contrib, n)
+	scale := math.Max(1, math.Abs(s))
+	r := f.Support * scale
+	for i := range cs {
+		c := c0 + float64(i)*s
+		lo := int(math.Ceil(c - r - 0.5))
+		hi := int(math.Floor(c + r - 0.5))
+		if border.transparent() && (hi < 0 || lo >= m) {
+			continue
+		}
+		var sum float64
+		var ct contrib
+		for j := lo; j <= hi; j++ {
+			w := f.Kernel((float64(j) + 0.5 - c) / scale)
+			if w == 0 {
+				continue
+			}
+			sum += w
+			k, ok := border.index(j, m)
+			if !ok {
+				if border.transparent() {
+					// Transparent, but still part of the sum.
+					continue
+				}
+				// The border color.
+				k = -1
+			}
+			ct.index = append(ct.index, k)
+			ct.weight = append(ct.weight, w)
+		}
+		if sum == 0 {
+			continue
+		}
+		for k := range ct.weight {
+			ct.weight[k] /= sum
+		}
+		cs[i] = ct
+	}
+	return cs
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// separable resamples src in two passes. The rows of src are first resampled
+// to width columns, then the columns to height rows.
+func (f Filter) separable(src image.Image, X0, sx, Y0, sy float64, width, height int, border BorderMode) image.Image {
+	b := src.Bounds()
+	xc := f.contribs(width, b.Dx(), X0, sx, border)
+	yc := f.contribs(height, b.Dy(), Y0, sy, border)
+	nc := channels(src)
+
+	// Resample only the source rows that are used.
+	used := make([]bool, b.Dy())
+	for _, c := range yc {
+		for _, j := range c.index {
+			if j >= 0 {
+				used[j] = true
+			}
+		}
+	}
+	bc := borderValues(src, border)
+	resample := func(row []float64) []float64 {
+		t := make([]float64, width*nc)
+		for x, c := range xc {
+			for k, i := range c.index {
+				w := c.weight[k]
+				v := bc
+				if i >= 0 {
+					v = row[i*nc : i*nc+nc]
+				}
+				for ch, vv := range v {
+					t[x*nc+ch] += w * vv
+				}
+			}
+		}
+		return t
+	}
+	tmp := make([][]float64, b.Dy())
+	parallel(b.Dy(), func(y0, y1 int) {
+		row := make([]float64, b.Dx()*nc)
+		for y := y0; y < y1; y++ {
+			if !used[y] {
+				continue
+			}
+			readRow(src, y, row)
+			tmp[y] = resample(row)
+		}
+	})
+	// The rows beyond a constant border.
+	row := make([]float64, b.Dx()*nc)
+	for i := range row {
+		row[i] = bc[i%nc]
+	}
+	edge := resample(row)
+
+	dst := newResampled(src, width, height)
+	parallel(height, func(y0, y1 int) {
+		acc := make([]float64, nc)
+		for y := y0; y < y1; y++ {
+			c := yc[y]
+			if len(c.index) == 0 {
+				continue
+			}
+			for x := range xc {
+				if len(xc[x].index) == 0 {
+					continue
+				}
+				for ch := range acc {
+					acc[ch] = 0
+				}
+				for k, j := range c.index {
+					w := c.weight[k]
+					t := edge[x*nc : x*nc+nc]
+					if j >= 0 {
+						t = tmp[j][x*nc : x*nc+nc]
+					}
+					for ch, v := range t {
+						acc[ch] += w * v
+					}
+				}
+				dst.set(x, y, acc)
+			}
+		}
+	})
+	return dst.Image
+}
+
+// general resamples src in one pass, for any transformation.
+func (f Filter) general(src image.Image, transform TransformFunc, width, height int, border BorderMode) image.Image {
+	b := src.Bounds()
+	nc := channels(src)
+	dst := newResampled(src, width, height)
+	// The 16 bit colors of getColor, scaled like readRow.
+	unit := 1.0
+	switch src.(type) {
+	case *image.RGBA, *image.Gray:
+		unit = 257
+	}
+	bc := borderValues(src, border)
+	// Beyond a transparent or constant border all pixels have the same
+	// color, so only the pixels of the source are visited.
+	clip := border.method() == borderConstant
+	parallel(height, func(y0, y1 int) {
+		acc := make([]float64, nc)
+		var wxs, wys []float64
+		for ydst := y0; ydst < y1; ydst++ {
+			for xdst := 0; xdst < width; xdst++ {
+				X, Y := transform(xdst, ydst)
+				if math.IsNaN(X) || math.IsNaN(Y) || math.IsInf(X, 0) || math.IsInf(Y, 0) {
+					continue
+				}
+				// The footprint of the destination pixel in the source.
+				Xx, Yx := transform(xdst+1, ydst)
+				Xy, Yy := transform(xdst, ydst+1)
+				scale := math.Max(math.Hypot(Xx-X, Yx-Y), math.Hypot(Xy-X, Yy-Y))
+				if !(scale <= maxFilterScale) {
+					// Also for NaN, at the edge of the domain of transform.
+					scale = maxFilterScale
+				}
+				scale = math.Max(1, scale)
+				r := f.Support * scale
+				sx0, sx1 := int(math.Ceil(X-r-0.5)), int(math.Floor(X+r-0.5))
+				sy0, sy1 := int(math.Ceil(Y-r-0.5)), int(math.Floor(Y+r-0.5))
+				// Are all neighbours outside the source image?
+				if border.transparent() && (sx1 < 0 || sy1 < 0 || sx0 >= b.Dx() || sy0 >= b.Dy()) {
+					continue
+				}
+				// The kernel is separable, the weight of pixel (x, y) is
+				// wxs[x - sx0] * wys[y - sy0].
+				var sumx, sumy float64
+				wxs, sumx = f.weights(wxs[:0], sx0, sx1, X, scale)
+				wys, sumy = f.weights(wys[:0], sy0, sy1, Y, scale)
+				sum := sumx * sumy
+				if sum == 0 {
+					continue
+				}
+				x0, x1, yy0, yy1 := sx0, sx1, sy0, sy1
+				if clip {
+					// The loops below are empty if there is no overlap.
+					if x0 < 0 {
+						x0 = 0
+					}
+					if x1 >= b.Dx() {
+						x1 = b.Dx() - 1
+					}
+					if yy0 < 0 {
+						yy0 = 0
+					}
+					if yy1 >= b.Dy() {
+						yy1 = b.Dy() - 1
+					}
+				}
+				for ch := range acc {
+					acc[ch] = 0
+				}
+				var inside float64
+				for y := yy0; y <= yy1; y++ {
+					wy := wys[y-sy0]
+					if wy == 0 {
+						continue
+					}
+					for x := x0; x <= x1; x++ {
+						w := wy * wxs[x-sx0]
+						if w == 0 {
+							continue
+						}
+						inside += w
+						r, g, bl, a := getColor(src, x+b.Min.X, y+b.Min.Y, border).RGBA()
+						if nc == 1 {
+							acc[0] += w * float64(g) / unit
+							continue
+						}
+						acc[0] += w * float64(r) / unit
+						acc[1] += w * float64(g) / unit
+						acc[2] += w * float64(bl) / unit
+						acc[3] += w * float64(a) / unit
+					}
+				}
+				if clip && !border.transparent() {
+					// The skipped pixels have the border color.
+					for ch := range acc {
+						acc[ch] += (sum - inside) * bc[ch]
+					}
+				}
+				for ch := range acc {
+					acc[ch] /= sum
+				}
+				dst.set(xdst, ydst, acc)
+			}
+		}
+	})
+	return dst.Image
+}
+
+// weights appends the kernel weights of pixels i0 to i1 for a destination
+// pixel with its center at c, to ws. It also returns their sum.
+func (f Filter) weights(ws []float64, i0, i1 int, c, scale float64) ([]float64, float64) {
+	var sum float64
+	for i := i0; i <= i1; i++ {
+		w := f.Kernel((float64(i) + 0.5 - c) / scale)
+		ws = append(ws, w)
+		sum += w
+	}
+	return ws, sum
+}
+
+// channels returns the number of channels the filters use for src: one for
+// gray images, four (premultiplied red, green, blue and alpha) otherwise.
+func channels(src image.Image) int {
+	if _, ok := src.(*image.Gray); ok {
+		return 1
+	}
+	return 4
+}
+
+// borderValues returns the channels of the color of a constant border, scaled
+// like readRow.
+func borderValues(src image.Image, border BorderMode) []float64 {
+	c := border.color()
+	switch src.(type) {
+	case *image.RGBA:
+		return []float64{float64(c.R) / 257, float64(c.G) / 257, float64(c.B) / 257, float64(c.A) / 257}
+	case *image.Gray:
+		return []float64{float64(color.GrayModel.Convert(c).(color.Gray).Y)}
+	}
+	return []float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+}
+
+// readRow reads row y (relative to the bounds) of src into row. The values
+// are 8 bit for *image.RGBA and *image.Gray, and 16 bit otherwise.
+func readRow(src image.Image, y int, row []float64) {
+	b := src.Bounds()
+	switch img := src.(type) {
+	case *image.RGBA:
+		p := img.Pix[y*img.Stride : y*img.Stride+4*b.Dx()]
+		for i, v := range p {
+			row[i] = float64(v)
+		}
+	case *image.Gray:
+		p := img.Pix[y*img.Stride : y*img.Stride+b.Dx()]
+		for i, v := range p {
+			row[i] = float64(v)
+		}
+	default:
+		for x := 0; x < b.Dx(); x++ {
+			r, g, bl, a := src.At(x+b.Min.X, y+b.Min.Y).RGBA()
+			row[4*x], row[4*x+1], row[4*x+2], row[4*x+3] = float64(r), float64(g), float64(bl), float64(a)
+		}
+	}
+}
+
+// resampled is the destination of a Filter, with a set method for the
+// channels produced by channels and readRow.
+type resampled struct {
+	image.Image
+	set func(x, y int, c []float64)
+}
+
+func newResampled(src image.Image, width, height int) resampled {
+	switch src.(type) {
+	case *image.RGBA:
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		return resampled{dst, func(x, y int, c []float64) {
+			// Negative lobes can overshoot, colors are premultiplied by alpha.
+			A := clamp(c[3], 255)
+			i := y*dst.Stride + 4*x
+			dst.Pix[i] = uint8(clamp(c[0], A) + 0.5)
+			dst.Pix[i+1] = uint8(clamp(c[1], A) + 0.5)
+			dst.Pix[i+2] = uint8(clamp(c[2], A) + 0.5)
+			dst.Pix[i+3] = uint8(A + 0.5)
+		}}
+	case *image.Gray:
+		dst := image.NewGray(image.Rect(0, 0, width, height))
+		return resampled{dst, func(x, y int, c []float64) {
+			dst.Pix[y*dst.Stride+x] = uint8(clamp(c[0], 255) + 0.5)
+		}}
+	}
+	dst := newImage(src, width, height)
+	return resampled{dst, func(x, y int, c []float64) {
+		A := clamp(c[3], 65535)
+		dst.Set(x, y, color.RGBA64{uint16(clamp(c[0], A) + 0.5), uint16(clamp(c[1], A) + 0.5), uint16(clamp(c[2], A) + 0.5), uint16(A + 0.5)})
+	}}
+}
@@ -0,0 +1,217 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+)
+
+var filters = map[string]Filter{
+	"Box":      Box,
+	"Triangle": Triangle,
+	"Lanczos2": Lanczos2,
+	"Lanczos3": Lanczos3,
+	"Gaussian": Gaussian,
+}
+
+func TestFilterBoxAverage(t *testing.T) {
+	src := testPattern()
+	m := Scale(src, 16, 12, Box.Interpolate).(*image.RGBA)
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 16; x++ {
+			var sum [4]int
+			for _, p := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				c := src.RGBAAt(2*x+p[0], 2*y+p[1])
+				sum[0] += int(c.R)
+				sum[1] += int(c.G)
+				sum[2] += int(c.B)
+				sum[3] += int(c.A)
+			}
+			c := m.RGBAAt(x, y)
+			got := [4]int{int(c.R), int(c.G), int(c.B), int(c.A)}
+			for i := range sum {
+				if want := (2*sum[i] + 4) / 8; got[i] != want {
+					t.Fatalf("(%d, %d) = %v, want the average of 2x2 pixels", x, y, c)
+				}
+			}
+		}
+	}
+}
+
+// TestFilterBoxLarge reduces by more than maxFilterScale, which only limits
+// the one-pass path.
+func TestFilterBoxLarge(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			if x%40 < 10 {
+				src.Pix[y*src.Stride+x] = 200
+			}
+		}
+	}
+	m := Scale(src, 10, 5, Box.Interpolate).(*image.Gray)
+	for i, v := range m.Pix {
+		if v != 50 {
+			t.Fatalf("pixel %d = %d, want the average 50", i, v)
+		}
+	}
+}
+
+func TestFilterTriangleBilinear(t *testing.T) {
+	// When enlarging, Triangle is Bilinear.
+	src := testPattern()
+	want := Scale(src, 71, 50, Bilinear)
+	compareImages(t, "Triangle", want, Scale(src, 71, 50, Triangle.Interpolate), 257)
+}
+
+func TestFilterConstant(t *testing.T) {
+	for _, src := range []image.Image{
+		image.NewRGBA(image.Rect(0, 0, 37, 29)),
+		image.NewGray(image.Rect(0, 0, 37, 29)),
+		image.NewNRGBA(image.Rect(0, 0, 37, 29)),
+	} {
+		c := color.RGBA{200, 100, 50, 255}
+		draw.Draw(src.(draw.Image), src.Bounds(), image.NewUniform(c), image.ZP, draw.Src)
+		want := src.At(0, 0)
+		for name, f := range filters {
+			for _, size := range [][2]int{{10, 7}, {37, 29}, {80, 61}} {
+				m := Scale(src, size[0], size[1], f.Interpolate)
+				b := m.Bounds()
+				for y := b.Min.Y; y < b.Max.Y; y++ {
+					for x := b.Min.X; x < b.Max.X; x++ {
+						if m.At(x, y) != want {
+							t.Fatalf("%s %T to %v: (%d, %d) = %v, want %v", name, src, size, x, y, m.At(x, y), want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestFilterAliasing reduces a checkerboard of single pixels, which should
+// become uniform gray instead of a moiré pattern.
+func TestFilterAliasing(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 200, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 200; x++ {
+			if (x+y)%2 == 0 {
+				src.Pix[y*src.Stride+x] = 255
+			}
+		}
+	}
+	deviation := func(m image.Image) float64 {
+		g := m.(*image.Gray)
+		var sum, sum2 float64
+		for _, v := range g.Pix {
+			sum += float64(v)
+			sum2 += float64(v) * float64(v)
+		}
+		n := float64(len(g.Pix))
+		return math.Sqrt(sum2/n - (sum/n)*(sum/n))
+	}
+
+	if d := deviation(Scale(src, 37, 37, Bilinear)); d < 20 {
+		t.Logf("Bilinear does not alias (deviation %v), the test is too easy", d)
+	}
+	for name, f := range filters {
+		if name == "Box" {
+			// Box reductions by a non-integer factor keep some aliasing.
+			continue
+		}
+		if d := deviation(Scale(src, 37, 37, f.Interpolate)); d > 8 {
+			t.Errorf("%s: deviation %v", name, d)
+		}
+	}
+}
+
+// TestFilterRotate uses the one-pass path.
+func TestFilterRotate(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.RGBA{0, 128, 255, 255}), image.ZP, draw.Src)
+	a := NewAffineMatrix()
+	a.AddRotation(0.4, 20, 20)
+	a.AddZoom(0.3, 0.3, 20, 20)
+	transform := func(x, y int) (float64, float64) {
+		X, Y := float64(x)+0.5, float64(y)+0.5
+		return X*a[0] + Y*a[1] + a[2], X*a[3] + Y*a[4] + a[5]
+	}
+	if _, _, _, _, ok := axisAligned(transform, 40, 40); ok {
+		t.Fatal("rotation is axis aligned")
+	}
+	for name, f := range filters {
+		m := f.Interpolate(src, transform, 40, 40, BORDER_COPY).(*image.RGBA)
+		if c := m.RGBAAt(20, 20); c != (color.RGBA{0, 128, 255, 255}) {
+			t.Errorf("%s: center = %v", name, c)
+		}
+	}
+}
+
+// TestFilterNonLinear shifts only the middle rows, which a scale or
+// translation fitted to a few points of the transformation would miss.
+func TestFilterNonLinear(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 60, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 60; x++ {
+			src.Pix[y*src.Stride+x] = uint8(4 * x)
+		}
+	}
+	transform := func(x, y int) (float64, float64) {
+		X, Y := float64(x)+0.5, float64(y)+0.5
+		if y >= 15 && y < 25 {
+			X += 10
+		}
+		return X, Y
+	}
+	if _, _, _, _, ok := axisAligned(transform, 60, 40); ok {
+		t.Fatal("shifted rows are axis aligned")
+	}
+	for name, f := range filters {
+		m := f.Interpolate(src, transform, 60, 40, BORDER_COPY).(*image.Gray)
+		if v := m.GrayAt(20, 20).Y; v < 118 || v > 122 {
+			t.Errorf("%s: shifted pixel = %d, want 120", name, v)
+		}
+		if v := m.GrayAt(20, 5).Y; v < 78 || v > 82 {
+			t.Errorf("%s: pixel = %d, want 80", name, v)
+		}
+	}
+}
+
+// TestFilterHorizon maps destination pixels near the horizon of a projective
+// transformation to huge footprints, the widening of the support is capped.
+func TestFilterHorizon(t *testing.T) {
+	src := testPattern()
+	var a AffineMatrix
+	a[0], a[4], a[8] = 1, 1, 1
+	a[7] = -1.0 / 20 // The horizon is at y = 20.
+	for _, border := range []BorderMode{BORDER_TRANSPARENT, BORDER_CONSTANT, BORDER_WRAP} {
+		Lanczos3.Interpolate(src, a.transform(0, 0), 64, 40, border)
+	}
+}
+
+// TestFilterConstantBorder checks the contribution of the border pixels,
+// which are not visited one by one for a constant border.
+func TestFilterConstantBorder(t *testing.T) {
+	c := color.RGBA{200, 100, 50, 255}
+	src := image.NewRGBA(image.Rect(0, 0, 37, 29))
+	draw.Draw(src, src.Bounds(), image.NewUniform(c), image.ZP, draw.Src)
+	a := NewAffineMatrix()
+	a.AddRotation(0.4, 18, 14)
+	a.AddZoom(0.1, 0.1, 18, 14)
+	for name, f := range filters {
+		m := f.Interpolate(src, a.transform(0, 0), 37, 29, BorderConstant(c)).(*image.RGBA)
+		for y := 0; y < 29; y++ {
+			for x := 0; x < 37; x++ {
+				if got := m.RGBAAt(x, y); got != c {
+					t.Fatalf("%s: (%d, %d) = %v, want %v", name, x, y, got, c)
+				}
+			}
+		}
+	}
+}
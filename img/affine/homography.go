@@ -0,0 +1,291 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"errors"
+	"image"
+	"math"
+)
+
+var (
+	// ErrDegenerate is returned when the points do not determine a
+	// transformation, for example because three of them are collinear.
+	ErrDegenerate = errors.New("affine: degenerate point configuration")
+
+	// ErrSingular is returned when a matrix has no inverse.
+	ErrSingular = errors.New("affine: matrix is singular")
+)
+
+// Point is a point in image coordinates. The origin is the top left corner of
+// the image bounds, and the center of the top left pixel is (0.5, 0.5).
+type Point struct {
+	X, Y float64
+}
+
+// Homography is a projective transformation, stored as a 3x3 matrix in row
+// major order. Unlike an AffineMatrix, a Homography maps points of the source
+// image to the destination image:
+//     [x']   [h0 h1 h2] [x]
+//     [y'] ~ [h3 h4 h5] [y]
+//     [w']   [h6 h7 h8] [1]
+// with the destination point (x'/w', y'/w').
+//
+// Homographies map straight lines to straight lines, which makes them suitable
+// for deskewing photographs of documents and for other perspective
+// corrections.
+type Homography [9]float64
+
+// Project returns the point that (x, y) is mapped to, including the
+// perspective divide.
+func (h Homography) Project(x, y float64) (float64, float64) {
+	w := x*h[6] + y*h[7] + h[8]
+	return (x*h[0] + y*h[1] + h[2]) / w, (x*h[3] + y*h[4] + h[5]) / w
+}
+
+// Inverse returns the homography that maps the destination back to the
+// source.
+func (h Homography) Inverse() (Homography, error) {
+	a := AffineMatrix(h)
+	det := a.det()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Homography{}, ErrSingular
+	}
+	return Homography(a.invertMatrix()), nil
+}
+
+// HomographyFromPoints returns the homography that maps the four points src
+// exactly to the four points dst. No three of the points may be collinear.
+func HomographyFromPoints(src, dst [4]Point) (Homography, error) {
+	// With h8 = 1 every correspondence gives two linear equations in the
+	// other eight elements:
+	//     x*h0 + y*h1 + h2 - x*x'*h6 - y*x'*h7 = x'
+	//     x*h3 + y*h4 + h5 - x*y'*h6 - y*y'*h7 = y'
+	var m [8][9]float64
+	for i := 0; i < 4; i++ {
+		x, y := src[i].X, src[i].Y
+		u, v := dst[i].X, dst[i].Y
+		m[2*i] = [9]float64{x, y, 1, 0, 0, 0, -x * u, -y * u, u}
+		m[2*i+1] = [9]float64{0, 0, 0, x, y, 1, -x * v, -y * v, v}
+	}
+
+	// Gaussian elimination with partial pivoting.
+	for c := 0; c < 8; c++ {
+		p := c
+		for r := c + 1; r < 8; r++ {
+			if math.Abs(m[r][c]) > math.Abs(m[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(m[p][c]) < 1e-12 {
+			return Homography{}, ErrDegenerate
+		}
+		m[c], m[p] = m[p], m[c]
+		for r := c + 1; r < 8; r++ {
+			f := m[r][c] / m[c][c]
+			for k := c; k < 9; k++ {
+				m[r][k] -= f * m[c][k]
+			}
+		}
+	}
+	var h Homography
+	for r := 7; r >= 0; r-- {
+		s := m[r][8]
+		for k := r + 1; k < 8; k++ {
+			s -= m[r][k] * h[k]
+		}
+		h[r] = s / m[r][r]
+	}
+	h[8] = 1
+	return h, nil
+}
+
+// EstimateHomography returns the homography that maps the points src to the
+// points dst with the least algebraic error, for four or more
+// correspondences.
+//
+// It uses the normalized direct linear transformation (DLT) algorithm: the
+// points are first moved and scaled so that their centroid is the origin and
+// their mean distance to it is √2, which makes the estimate much less
+// sensitive to noise.
+//
+//     R. Hartley and A. Zisserman, 2003.
+//     Multiple View Geometry in Computer Vision, second edition.
+//     Algorithm 4.2.
+func EstimateHomography(src, dst []Point) (Homography, error) {
+	n := len(src)
+	if n != len(dst) {
+		panic("affine.EstimateHomography: number of points does not match.")
+	}
+	if n < 4 {
+		return Homography{}, ErrDegenerate
+	}
+	ts, ok := normalization(src)
+	if !ok {
+		return Homography{}, ErrDegenerate
+	}
+	td, ok := normalization(dst)
+	if !ok {
+		return Homography{}, ErrDegenerate
+	}
+
+	// Every correspondence gives two rows of A, where A * h = 0.
+	a := make([][9]float64, 0, 2*n)
+	for i := 0; i < n; i++ {
+		x, y := ts.Project(src[i].X, src[i].Y)
+		u, v := td.Project(dst[i].X, dst[i].Y)
+		a = append(a,
+			[9]float64{-x, -y, -1, 0, 0, 0, u * x, u * y, u},
+			[9]float64{0, 0, 0, -x, -y, -1, v * x, v * y, v})
+	}
+
+	// h is the right singular vector for the smallest singular value.
+	σ, V := svdColumns(a)
+	min, next := 0, -1
+	for j := 1; j < 9; j++ {
+		switch {
+		case σ[j] < σ[min]:
+			min, next = j, min
+		case next < 0 || σ[j] < σ[next]:
+			next = j
+		}
+	}
+	max := 0.0
+	for _, s := range σ {
+		max = math.Max(max, s)
+	}
+	if σ[next] <= 1e-10*max {
+		// The null space has more than one dimension.
+		return Homography{}, ErrDegenerate
+	}
+	var hn Homography
+	for i := range hn {
+		hn[i] = V[i][min]
+	}
+
+	// Undo the normalization: H = inverse(Td) * Hn * Ts.
+	tdInv, err := td.Inverse()
+	if err != nil {
+		return Homography{}, ErrDegenerate
+	}
+	h := AffineMatrix(tdInv)
+	h.mul(AffineMatrix(hn))
+	h.mul(AffineMatrix(ts))
+	return Homography(h).normalize(), nil
+}
+
+// ApplyHomography applies homography h to image.Image src, and returns an
+// image of width x height pixels.
+//
+// InterpolationFunc interpolate is the interpolation function to be used, as
+// for Apply.
+//
+// ApplyHomography panics if h is singular. A homography estimated from user
+// data can be, check that h.Inverse does not return ErrSingular first.
+func ApplyHomography(h Homography, src image.Image, width, height int, interpolate InterpolationFunc) image.Image {
+	inv, err := h.Inverse()
+	if err != nil {
+		panic("affine.ApplyHomography: homography is singular.")
+	}
+	t := func(x, y int) (float64, float64) {
+		return inv.Project(float64(x)+0.5, float64(y)+0.5)
+	}
+	return interpolate(src, t, width, height, BORDER_TRANSPARENT)
+}
+
+// normalize scales h so that h[8] is 1, or h has unit norm if h[8] is 0.
+func (h Homography) normalize() Homography {
+	s := h[8]
+	if math.Abs(s) < 1e-12 {
+		s = 0
+		for _, x := range h {
+			s += x * x
+		}
+		s = math.Sqrt(s)
+	}
+	for i := range h {
+		h[i] /= s
+	}
+	return h
+}
+
+// normalization returns the similarity transform that moves the centroid of
+// the points to the origin and scales their mean distance to it to √2.
+func normalization(p []Point) (Homography, bool) {
+	var cx, cy float64
+	for _, q := range p {
+		cx += q.X
+		cy += q.Y
+	}
+	cx /= float64(len(p))
+	cy /= float64(len(p))
+	d := 0.0
+	for _, q := range p {
+		d += math.Hypot(q.X-cx, q.Y-cy)
+	}
+	d /= float64(len(p))
+	if d == 0 {
+		return Homography{}, false
+	}
+	s := math.Sqrt2 / d
+	return Homography{s, 0, -s * cx, 0, s, -s * cy, 0, 0, 1}, true
+}
+
+// svdColumns returns the singular values and the right singular vectors (as
+// the columns of V) of the matrix with rows a.
+//
+// It uses the one-sided Jacobi method, which orthogonalises the columns of a
+// with plane rotations and is accurate for the small systems used here.
+func svdColumns(rows [][9]float64) (σ [9]float64, V [9][9]float64) {
+	a := make([][9]float64, len(rows))
+	copy(a, rows)
+	for i := range V {
+		V[i][i] = 1
+	}
+
+	for sweep := 0; sweep < 60; sweep++ {
+		rotated := false
+		for p := 0; p < 8; p++ {
+			for q := p + 1; q < 9; q++ {
+				var α, β, γ float64
+				for _, r := range a {
+					α += r[p] * r[p]
+					β += r[q] * r[q]
+					γ += r[p] * r[q]
+				}
+				if γ == 0 || math.Abs(γ) <= 1e-15*math.Sqrt(α*β) {
+					continue
+				}
+				rotated = true
+				ζ := (β - α) / (2 * γ)
+				t := 1 / (math.Abs(ζ) + math.Sqrt(1+ζ*ζ))
+				if ζ < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+				for i := range a {
+					x, y := a[i][p], a[i][q]
+					a[i][p], a[i][q] = c*x-s*y, s*x+c*y
+				}
+				for i := range V {
+					x, y := V[i][p], V[i][q]
+					V[i][p], V[i][q] = c*x-s*y, s*x+c*y
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	for j := range σ {
+		for _, r := range a {
+			σ[j] += r[j] * r[j]
+		}
+		σ[j] = math.Sqrt(σ[j])
+	}
+	return σ, V
+}
@@ -0,0 +1,139 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var testHomography = Homography{1.2, 0.1, 5, -0.05, 0.9, 3, 0.001, -0.0005, 1}
+
+func closePoint(p Point, x, y, ε float64) bool {
+	return math.Abs(p.X-x) <= ε && math.Abs(p.Y-y) <= ε
+}
+
+func TestHomographyFromPoints(t *testing.T) {
+	src := [4]Point{{0, 0}, {100, 0}, {100, 80}, {0, 80}}
+	var dst [4]Point
+	for i, p := range src {
+		dst[i].X, dst[i].Y = testHomography.Project(p.X, p.Y)
+	}
+	h, err := HomographyFromPoints(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range h {
+		if math.Abs(h[i]-testHomography[i]) > 1e-9 {
+			t.Fatalf("h = %v, want %v", h, testHomography)
+		}
+	}
+
+	// Three collinear points.
+	src[1] = Point{50, 40}
+	src[2] = Point{100, 80}
+	if _, err := HomographyFromPoints(src, dst); err != ErrDegenerate {
+		t.Errorf("collinear points: err = %v, want ErrDegenerate", err)
+	}
+}
+
+func TestEstimateHomography(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var src, dst []Point
+	for i := 0; i < 30; i++ {
+		p := Point{rnd.Float64() * 640, rnd.Float64() * 480}
+		x, y := testHomography.Project(p.X, p.Y)
+		src = append(src, p)
+		dst = append(dst, Point{x + rnd.NormFloat64()*0.01, y + rnd.NormFloat64()*0.01})
+	}
+	h, err := EstimateHomography(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []Point{{0, 0}, {640, 0}, {320, 240}, {0, 480}} {
+		x, y := testHomography.Project(p.X, p.Y)
+		q := Point{}
+		q.X, q.Y = h.Project(p.X, p.Y)
+		if !closePoint(q, x, y, 0.05) {
+			t.Errorf("%v maps to %v, want (%v, %v)", p, q, x, y)
+		}
+	}
+
+	if _, err := EstimateHomography(src[:3], dst[:3]); err != ErrDegenerate {
+		t.Errorf("3 points: err = %v, want ErrDegenerate", err)
+	}
+	line := []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}}
+	if _, err := EstimateHomography(line, line); err != ErrDegenerate {
+		t.Errorf("collinear points: err = %v, want ErrDegenerate", err)
+	}
+}
+
+func TestHomographyInverse(t *testing.T) {
+	inv, err := testHomography.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := testHomography.Project(17, 42)
+	p := Point{}
+	p.X, p.Y = inv.Project(x, y)
+	if !closePoint(p, 17, 42, 1e-9) {
+		t.Errorf("inverse maps back to %v", p)
+	}
+	if _, err := (Homography{}).Inverse(); err != ErrSingular {
+		t.Errorf("err = %v, want ErrSingular", err)
+	}
+}
+
+func TestApplyHomography(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 40, 40))
+	for y := 10; y < 20; y++ {
+		for x := 10; x < 20; x++ {
+			src.SetGray(x, y, color.Gray{255})
+		}
+	}
+
+	// A pure translation by (5, 7) as a homography.
+	h := Homography{1, 0, 5, 0, 1, 7, 0, 0, 1}
+	dst := ApplyHomography(h, src, 40, 40, Nearest).(*image.Gray)
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			want := src.GrayAt(x-5, y-7)
+			if got := dst.GrayAt(x, y); got != want {
+				t.Fatalf("(%d, %d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// Apply divides by the bottom row.
+	a := AffineMatrix{2, 0, 0, 0, 2, 0, 0, 0, 2}
+	if d := Apply(a, src, Nearest).(*image.Gray); d.GrayAt(15, 15).Y != 255 || d.GrayAt(5, 5).Y != 0 {
+		t.Error("Apply ignores the perspective divide")
+	}
+}
+
+// TestAffineNoDivide checks that only projective matrices are divided by w.
+func TestAffineNoDivide(t *testing.T) {
+	// The zero matrix maps every pixel to the origin, as before projective
+	// matrices were supported.
+	var zero AffineMatrix
+	if x, y := zero.transform(0, 0)(3, 4); x != 0 || y != 0 {
+		t.Errorf("zero matrix: (%v, %v), want (0, 0)", x, y)
+	}
+	if p := zero.SourcePoint(Point{3, 4}); p.X != 0 || p.Y != 0 {
+		t.Errorf("zero matrix: SourcePoint = %v, want (0, 0)", p)
+	}
+
+	// An affine matrix with a[8] != 1 is normalized.
+	a := AffineMatrix{2, 0, 4, 0, 2, 6, 0, 0, 2}
+	if x, y := a.transform(0, 0)(3, 4); x != 5.5 || y != 7.5 {
+		t.Errorf("scaled matrix: (%v, %v), want (5.5, 7.5)", x, y)
+	}
+	if p, ok := project(a, Point{3, 4}); !ok || p.X != 5 || p.Y != 7 {
+		t.Errorf("scaled matrix: project = %v, %v, want (5, 7), true", p, ok)
+	}
+}
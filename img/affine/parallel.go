@@ -0,0 +1,61 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// workers is the number of goroutines used by the interpolation functions.
+var workers = int32(runtime.GOMAXPROCS(0))
+
+// minRows is the smallest number of destination rows given to a goroutine.
+const minRows = 8
+
+// SetWorkers sets the number of goroutines that the interpolation functions
+// split the destination rows over. If n < 1 it is set to GOMAXPROCS, and
+// n == 1 makes them serial.
+//
+// The result does not depend on the number of workers. The TransformFunc is
+// called from several goroutines at once, so it must be safe for concurrent
+// use; the transformations of this package are.
+func SetWorkers(n int) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	atomic.StoreInt32(&workers, int32(n))
+}
+
+// Workers returns the number of goroutines used by the interpolation
+// functions.
+func Workers() int {
+	return int(atomic.LoadInt32(&workers))
+}
+
+// parallel calls f for bands of rows [y0, y1) that together cover height
+// rows, each band in its own goroutine.
+func parallel(height int, f func(y0, y1 int)) {
+	n := Workers()
+	if n > height/minRows {
+		n = height / minRows
+	}
+	if n <= 1 {
+		f(0, height)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(n - 1)
+	for i := 1; i < n; i++ {
+		go func(y0, y1 int) {
+			f(y0, y1)
+			wg.Done()
+		}(i*height/n, (i+1)*height/n)
+	}
+	f(0, height/n)
+	wg.Wait()
+}
@@ -0,0 +1,70 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"testing"
+)
+
+// TestParallelDeterministic compares every interpolation function with the
+// serial result, which must be identical.
+func TestParallelDeterministic(t *testing.T) {
+	defer SetWorkers(0)
+
+	rgba := Scale(testPattern(), 130, 97, Bilinear).(*image.RGBA)
+	gray := image.NewGray(rgba.Bounds())
+	draw.Draw(gray, gray.Bounds(), rgba, image.ZP, draw.Src)
+	nrgba := image.NewNRGBA(rgba.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), rgba, image.ZP, draw.Src)
+
+	interpolations := map[string]InterpolationFunc{
+		"Nearest":  Nearest,
+		"Bilinear": Bilinear,
+		"Bicubic":  Bicubic,
+		"BSpline":  BSpline.Interpolate,
+		"Lanczos3": Lanczos3.Interpolate,
+		"Gaussian": Gaussian.Interpolate,
+	}
+	a := NewAffineMatrix()
+	a.AddRotation(0.5, 65, 48)
+	a.AddZoom(0.6, 0.6, 65, 48)
+
+	for _, src := range []image.Image{rgba, gray, nrgba} {
+		for name, f := range interpolations {
+			SetWorkers(1)
+			want := [][]byte{pix(Apply(a, src, f)), pix(Scale(src, 61, 203, f))}
+			SetWorkers(7)
+			got := [][]byte{pix(Apply(a, src, f)), pix(Scale(src, 61, 203, f))}
+			for i := range want {
+				if !bytes.Equal(want[i], got[i]) {
+					t.Errorf("%s %T: parallel result differs", name, src)
+				}
+			}
+		}
+	}
+}
+
+func pix(m image.Image) []byte {
+	switch m := m.(type) {
+	case *image.RGBA:
+		return m.Pix
+	case *image.Gray:
+		return m.Pix
+	case *image.NRGBA:
+		return m.Pix
+	}
+	panic("pix: unexpected image type")
+}
+
+func TestSetWorkers(t *testing.T) {
+	defer SetWorkers(0)
+	SetWorkers(3)
+	if n := Workers(); n != 3 {
+		t.Errorf("Workers() = %d, want 3", n)
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 // Scale returns a copy of the original image scaled to the specified width and
 // height. Scale does change the canvas size.
+//
+// Nearest, Bilinear and Bicubic alias when the image is made much smaller,
+// the Interpolate method of a Filter such as Lanczos3 does not.
 func Scale(src image.Image, width, height int, interpolate InterpolationFunc) image.Image {
 	b := src.Bounds()
 	wx := float64(b.Dx()) / float64(width)
@@ -0,0 +1,113 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"math"
+)
+
+// LensDistortion is the Brown-Conrady model of a camera lens, with the
+// intrinsic parameters of the camera: the focal lengths Fx and Fy and the
+// principal point (Cx, Cy), all in pixels.
+//
+// A point (x, y) of the ideal (pinhole) image, relative to the principal point
+// and divided by the focal lengths, is found in the camera image at
+//     x' = x (1 + K1 r² + K2 r⁴ + K3 r⁶) + 2 P1 x y + P2 (r² + 2 x²)
+//     y' = y (1 + K1 r² + K2 r⁴ + K3 r⁶) + P1 (r² + 2 y²) + 2 P2 x y
+// with r² = x² + y². K1, K2 and K3 are the radial and P1 and P2 the tangential
+// coefficients, as estimated by common camera calibration tools.
+//
+//     D. C. Brown, 1966.
+//     Decentering distortion of lenses.
+//     Photogrammetric Engineering, 32(3), 444-462.
+type LensDistortion struct {
+	Fx, Fy, Cx, Cy float64
+	K1, K2, K3     float64
+	P1, P2         float64
+}
+
+// Undistort returns the TransformFunc that removes lens distortion d from
+// camera images, for example:
+//     m := affine.Bilinear(photo, affine.Undistort(d), width, height, affine.BORDER_TRANSPARENT)
+func Undistort(d LensDistortion) TransformFunc {
+	return func(x, y int) (float64, float64) {
+		// The model maps the undistorted destination to the distorted
+		// source, as a TransformFunc does.
+		X := (float64(x) + 0.5 - d.Cx) / d.Fx
+		Y := (float64(y) + 0.5 - d.Cy) / d.Fy
+		r2 := X*X + Y*Y
+		radial := 1 + r2*(d.K1+r2*(d.K2+r2*d.K3))
+		Xd := X*radial + 2*d.P1*X*Y + d.P2*(r2+2*X*X)
+		Yd := Y*radial + d.P1*(r2+2*Y*Y) + 2*d.P2*X*Y
+		return Xd*d.Fx + d.Cx, Yd*d.Fy + d.Cy
+	}
+}
+
+// BarrelDistortion returns the TransformFunc that distorts an image of width x
+// height pixels radially around its center: a destination point at distance r
+// from the center shows the source at distance r (1 + k r²), with r relative
+// to half the diagonal. Positive k gives barrel distortion, negative k
+// pincushion distortion.
+func BarrelDistortion(k float64, width, height int) TransformFunc {
+	xcenter := float64(width) / 2
+	ycenter := float64(height) / 2
+	// Half the diagonal.
+	r0 := math.Hypot(xcenter, ycenter)
+	return func(x, y int) (float64, float64) {
+		X := (float64(x) + 0.5 - xcenter) / r0
+		Y := (float64(y) + 0.5 - ycenter) / r0
+		f := 1 + k*(X*X+Y*Y)
+		return X*f*r0 + xcenter, Y*f*r0 + ycenter
+	}
+}
+
+// Polar returns the TransformFunc that unwraps the disc around (xcenter,
+// ycenter) with the given radius into a destination image of width x height
+// pixels. The angle increases from 0 to 2π along the destination rows, in the
+// direction from the positive x axis to the positive y axis, and the radius
+// from 0 to radius down the columns.
+func Polar(xcenter, ycenter, radius float64, width, height int) TransformFunc {
+	return func(x, y int) (float64, float64) {
+		θ := 2 * math.Pi * (float64(x) + 0.5) / float64(width)
+		r := radius * (float64(y) + 0.5) / float64(height)
+		return xcenter + r*math.Cos(θ), ycenter + r*math.Sin(θ)
+	}
+}
+
+// LogPolar is Polar with the logarithm of the radius down the columns, from
+// 1 to radius, so that scaling the source around the center shifts the result
+// vertically, and rotating it shifts the result horizontally.
+func LogPolar(xcenter, ycenter, radius float64, width, height int) TransformFunc {
+	if radius <= 1 {
+		panic("affine.LogPolar: radius must be larger than 1.")
+	}
+	logr := math.Log(radius)
+	return func(x, y int) (float64, float64) {
+		θ := 2 * math.Pi * (float64(x) + 0.5) / float64(width)
+		r := math.Exp(logr * (float64(y) + 0.5) / float64(height))
+		return xcenter + r*math.Cos(θ), ycenter + r*math.Sin(θ)
+	}
+}
+
+// Remap returns the TransformFunc that moves every destination pixel by the
+// displacement stored in image field: pixel (x, y) of the destination shows
+// the source at (x + dx, y + dy), with (x, y) relative to the field bounds.
+//
+// The red channel holds dx and the green channel dy, as 16 bit color values v
+// for a displacement of (v - 0x8000) / 0x8000 * scale pixels. Use a 16 bit
+// image such as *image.NRGBA64, 8 bit images cannot store 0x8000. The field
+// should be opaque, and destination pixels beyond it are not moved.
+func Remap(field image.Image, scale float64) TransformFunc {
+	b := field.Bounds()
+	return func(x, y int) (float64, float64) {
+		X, Y := float64(x)+0.5, float64(y)+0.5
+		if x >= b.Dx() || y >= b.Dy() {
+			return X, Y
+		}
+		r, g, _, _ := field.At(x+b.Min.X, y+b.Min.Y).RGBA()
+		return X + (float64(r)-0x8000)/0x8000*scale, Y + (float64(g)-0x8000)/0x8000*scale
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package affine
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+)
+
+func TestUndistort(t *testing.T) {
+	d := LensDistortion{Fx: 500, Fy: 480, Cx: 320, Cy: 240}
+	identity := Undistort(d)
+	for _, p := range [][2]int{{0, 0}, {100, 50}, {639, 479}} {
+		X, Y := identity(p[0], p[1])
+		if !closePoint(Point{X, Y}, float64(p[0])+0.5, float64(p[1])+0.5, 1e-9) {
+			t.Errorf("no distortion: %v -> (%v, %v)", p, X, Y)
+		}
+	}
+
+	d.K1, d.K2, d.K3, d.P1, d.P2 = -0.2, 0.05, 0.01, 0.001, -0.002
+	d.Cx, d.Cy = 319.5, 239.5
+	X, Y := Undistort(d)(519, 119)
+	// By hand: x = 0.4, y = -0.25.
+	x, y := 0.4, -0.25
+	r2 := x*x + y*y
+	radial := 1 - 0.2*r2 + 0.05*r2*r2 + 0.01*r2*r2*r2
+	xd := x*radial + 2*0.001*x*y - 0.002*(r2+2*x*x)
+	yd := y*radial + 0.001*(r2+2*y*y) - 2*0.002*x*y
+	if !closePoint(Point{X, Y}, 500*xd+319.5, 480*yd+239.5, 1e-9) {
+		t.Errorf("(519, 119) -> (%v, %v), want (%v, %v)", X, Y, 500*xd+319.5, 480*yd+239.5)
+	}
+	// The principal point does not move.
+	if X, Y := Undistort(d)(319, 239); !closePoint(Point{X, Y}, 319.5, 239.5, 1e-9) {
+		t.Errorf("center -> (%v, %v)", X, Y)
+	}
+}
+
+func TestBarrelDistortion(t *testing.T) {
+	for _, k := range []float64{0, 0.2, -0.2} {
+		f := BarrelDistortion(k, 64, 48)
+		// The corner pixel at distance r of almost 1.
+		X, Y := f(63, 47)
+		r := math.Hypot(31.5, 23.5) / 40
+		want := Point{32 + 31.5*(1+k*r*r), 24 + 23.5*(1+k*r*r)}
+		if !closePoint(Point{X, Y}, want.X, want.Y, 1e-9) {
+			t.Errorf("k = %v: corner -> (%v, %v), want %v", k, X, Y, want)
+		}
+	}
+
+	// Barrel distortion shrinks the content, the corners become transparent.
+	src := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.ZP, draw.Src)
+	m := Bilinear(src, BarrelDistortion(0.3, 64, 48), 64, 48, BORDER_TRANSPARENT).(*image.RGBA)
+	if m.RGBAAt(0, 0).A != 0 || m.RGBAAt(32, 24).A != 255 {
+		t.Errorf("corner %v, center %v", m.RGBAAt(0, 0), m.RGBAAt(32, 24))
+	}
+}
+
+func TestPolar(t *testing.T) {
+	// Rings of width 4 around (50, 40) unwrap to horizontal stripes.
+	src := image.NewGray(image.Rect(0, 0, 100, 80))
+	for y := 0; y < 80; y++ {
+		for x := 0; x < 100; x++ {
+			r := math.Hypot(float64(x)+0.5-50, float64(y)+0.5-40)
+			src.Pix[y*src.Stride+x] = uint8(int(r/4) % 2 * 255)
+		}
+	}
+	m := Nearest(src, Polar(50, 40, 36, 90, 9), 90, 9, BORDER_TRANSPARENT).(*image.Gray)
+	for y := 0; y < 9; y++ {
+		want := uint8(y % 2 * 255)
+		for x := 0; x < 90; x++ {
+			if g := m.Pix[y*m.Stride+x]; g != want {
+				t.Fatalf("(%d, %d) = %d, want %d", x, y, g, want)
+			}
+		}
+	}
+
+	f := Polar(50, 40, 36, 360, 36)
+	if X, Y := f(89, 35); !closePoint(Point{X, Y}, 50+35.5*cosDeg(89.5), 40+35.5*cosDeg(0.5), 1e-9) {
+		t.Errorf("90° -> (%v, %v)", X, Y)
+	}
+}
+
+func cosDeg(d float64) float64 {
+	return math.Cos(d * math.Pi / 180)
+}
+
+func TestLogPolar(t *testing.T) {
+	f := LogPolar(50, 40, 100, 360, 100)
+	// The radius grows by the same factor every row.
+	X0, _ := f(359, 0)
+	X1, _ := f(359, 1)
+	X2, _ := f(359, 2)
+	if q0, q1 := (X1-50)/(X0-50), (X2-50)/(X1-50); math.Abs(q0-q1) > 1e-9 || math.Abs(q0-math.Pow(100, 0.01)) > 1e-3 {
+		t.Errorf("ratios %v and %v", q0, q1)
+	}
+	if X, Y := f(359, 99); !closePoint(Point{X, Y}, 50+math.Pow(100, 0.995)*cosDeg(359.5), 40-math.Pow(100, 0.995)*math.Sin(0.5*math.Pi/180), 1e-9) {
+		t.Errorf("outer radius -> (%v, %v)", X, Y)
+	}
+}
+
+func TestRemap(t *testing.T) {
+	src := testPattern()
+	b := src.Bounds()
+	field := image.NewNRGBA64(b)
+	draw.Draw(field, b, image.NewUniform(color.NRGBA64{0x8000, 0x8000, 0, 0xffff}), image.ZP, draw.Src)
+	compareImages(t, "no displacement", src, Nearest(src, Remap(field, 10), b.Dx(), b.Dy(), BORDER_TRANSPARENT), 0)
+
+	// Every pixel shows the source 5 pixels to the right and 2.5 up.
+	draw.Draw(field, b, image.NewUniform(color.NRGBA64{0xc000, 0x6000, 0, 0xffff}), image.ZP, draw.Src)
+	a := NewAffineMatrix()
+	a.AddTranslation(-5, 2.5)
+	want := Apply(a, src, Bilinear)
+	compareImages(t, "shift", want, Bilinear(src, Remap(field, 10), b.Dx(), b.Dy(), BORDER_TRANSPARENT), 0)
+}
@@ -0,0 +1,118 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import "math"
+
+// Variant identifies one of the Strassen-like multiplication algorithms.
+type Variant int
+
+const (
+	// Strassen's original algorithm, see MulStrassen.
+	Strassen Variant = iota
+	// The Strassen-Winograd variant, see MulWinograd.
+	Winograd
+	// The Strassen-Winograd variant with Douglas memory placement, see MulDouglas.
+	Douglas
+	// The Strassen-Winograd variant with Huss memory placement, see MulHuss.
+	Huss
+)
+
+// unitRoundoff is the unit roundoff of float64 arithmetic.
+const unitRoundoff = 1.0 / (1 << 53)
+
+// MulDepth returns A * B calculated with variant v, using at most depth levels
+// of recursion before falling back to MulBLAS.
+//
+// Every level of recursion makes the multiplication faster but less accurate,
+// see ErrorBound. A negative depth does not limit the recursion.
+func MulDepth(v Variant, A, B *Matrix, depth int) *Matrix {
+	return Zeros(A.height, B.width).MulDepth(v, A, B, depth)
+}
+
+// MulDepth calculates C = A * B with variant v, using at most depth levels of
+// recursion, and returns C.
+func (C *Matrix) MulDepth(v Variant, A, B *Matrix, depth int) *Matrix {
+	switch v {
+	case Strassen:
+		C.Clear()
+		return C.mulStrassen(A, B, depth)
+	case Winograd:
+		return C.mulWinograd(A, B, depth)
+	case Douglas:
+		C.mulDouglas(A, B, nil, depth)
+	case Huss:
+		C.Clear()
+		C.mulAddHuss(A, B, nil, depth)
+	default:
+		panic("matrix.MulDepth: unknown variant.")
+	}
+	return C
+}
+
+// Levels returns the number of levels of recursion variant v uses to multiply
+// A and B, when limited to depth levels (unlimited if depth < 0).
+func Levels(v Variant, A, B *Matrix, depth int) int {
+	levels := 0
+	m, k, n := A.height, A.width, B.width
+	for levels != depth && k >= 80 {
+		if v == Douglas {
+			if m % 2 != 0 || k % 2 != 0 || n % 2 != 0 {
+				break
+			}
+		} else if m != k || m % 2 != 0 {
+			break
+		}
+		m, k, n = m / 2, k / 2, n / 2
+		levels++
+	}
+	return levels
+}
+
+// ErrorBound returns a bound on the largest absolute error of an element of
+// MulDepth(v, A, B, depth).
+//
+// The bound holds to first order in the unit roundoff u. For n x n matrices
+// that are split l times (see Levels) into blocks of size n0 = n / 2^l it is
+//
+//	Strassen:  ((n/n0)^log2(12) (n0^2 + 5 n0) - 5n) u max|A| max|B|
+//	Winograd:  ((n/n0)^log2(18) (n0^2 + 6 n0) - 6n) u max|A| max|B|
+//
+// where the Winograd bound also holds for Douglas and Huss. Without recursion
+// (l = 0) both reduce to the bound n^2 u max|A| max|B| of the conventional
+// algorithm. The bounds grow much faster with l than the actual errors do, so
+// they are mostly useful to compare variants and depths.
+//
+//	See:
+//	N. J. Higham, 2002.
+//	Accuracy and Stability of Numerical Algorithms, chapter 23.
+func ErrorBound(v Variant, A, B *Matrix, depth int) float64 {
+	n := float64(A.width)
+	l := Levels(v, A, B, depth)
+	n0 := n / math.Ldexp(1, l)
+
+	var f float64
+	switch v {
+	case Strassen:
+		f = math.Pow(n / n0, math.Log2(12)) * (n0 * n0 + 5 * n0) - 5 * n
+	case Winograd, Douglas, Huss:
+		f = math.Pow(n / n0, math.Log2(18)) * (n0 * n0 + 6 * n0) - 6 * n
+	default:
+		panic("matrix.ErrorBound: unknown variant.")
+	}
+
+	return f * unitRoundoff * maxAbs(A) * maxAbs(B)
+}
+
+// maxAbs returns the largest absolute value of an element of A.
+func maxAbs(A *Matrix) float64 {
+	max := 0.0
+	for i := 0; i < A.height; i++ {
+		for _, aij := range A.Row(i) {
+			max = math.Max(max, math.Abs(aij))
+		}
+	}
+	return max
+}
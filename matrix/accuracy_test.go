@@ -0,0 +1,101 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+// maxError returns the largest absolute difference between A and B.
+func maxError(A, B *Matrix) float64 {
+	return maxAbs(Minus(A, B))
+}
+
+func TestMulAccurate(t *testing.T) {
+	// The naive product cancels 1 against 1e16.
+	A := New(2, 3, []float64{1e16, 1, -1e16, 1, 2, 3})
+	B := New(3, 1, []float64{1, 1, 1})
+	C := MulAccurate(A, B)
+
+	correct := New(2, 1, []float64{1, 6})
+	if !equal(C, correct, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulAccurateNaive(t *testing.T) {
+	n := 100
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(A, B)
+	D := MulAccurate(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestLevels(t *testing.T) {
+	A := Zeros(400, 400)
+	for depth, levels := range []int{0, 1, 2, 3, 3} {
+		if l := Levels(Douglas, A, A, depth); l != levels {
+			t.Errorf("Levels(%d) = %d, expected %d", depth, l, levels)
+		}
+	}
+	if l := Levels(Strassen, A, A, -1); l != 3 {
+		t.Errorf("Levels(-1) = %d, expected 3", l)
+	}
+}
+
+func TestErrorBound(t *testing.T) {
+	n := 320
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulAccurate(A, B)
+
+	for _, v := range []Variant{Strassen, Winograd, Douglas, Huss} {
+		previous := 0.0
+		for depth := 0; depth <= 2; depth++ {
+			err := maxError(C, MulDepth(v, A, B, depth))
+			bound := ErrorBound(v, A, B, depth)
+			t.Logf("variant %d, depth %d: error %.3g, bound %.3g", v, depth, err, bound)
+
+			if err > bound {
+				t.Errorf("Variant %d, depth %d: error %v exceeds bound %v", v, depth, err, bound)
+			}
+			if bound < previous {
+				t.Errorf("Variant %d, depth %d: bound %v decreases", v, depth, bound)
+			}
+			previous = bound
+		}
+	}
+}
+
+func TestMulDepthZero(t *testing.T) {
+	n := 200
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulBLAS(A, B)
+
+	for _, v := range []Variant{Strassen, Winograd, Douglas, Huss} {
+		if !equal(C, MulDepth(v, A, B, 0), 0, t) {
+			t.Fatalf("Variant %d with depth 0 differs from MulBLAS", v)
+		}
+	}
+}
+
+func TestErrorBoundConventional(t *testing.T) {
+	n := 50
+	A := Ones(n, n)
+	B := Ones(n, n)
+	B.Scale(2)
+
+	// Without recursion the bound is n^2 u max|A| max|B|.
+	bound := ErrorBound(Strassen, A, B, -1)
+	if correct := float64(n * n) * 2 * math.Ldexp(1, -53); bound != correct {
+		t.Fatalf("ErrorBound = %v, expected %v", bound, correct)
+	}
+}
@@ -11,6 +11,17 @@ func Plus(A, B *Matrix) *Matrix {
 
 // Add calculates A = A + B and returns A.
 func (A *Matrix) Add(B *Matrix) *Matrix {
+	if parallel(A) {
+		a, b := *A, *B
+		inBands(a.height, func(i0, i1 int) {
+			a.band(i0, i1).add(b.band(i0, i1))
+		})
+		return A
+	}
+	return A.add(B)
+}
+
+func (A *Matrix) add(B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width {
@@ -33,6 +44,17 @@ func (A *Matrix) Add(B *Matrix) *Matrix {
 
 // Plus calculates C = A + B and returns C.
 func (C *Matrix) Plus(A, B *Matrix) *Matrix  {
+	if parallel(C) {
+		c, a, b := *C, *A, *B
+		inBands(c.height, func(i0, i1 int) {
+			c.band(i0, i1).plus(a.band(i0, i1), b.band(i0, i1))
+		})
+		return C
+	}
+	return C.plus(A, B)
+}
+
+func (C *Matrix) plus(A, B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width && C.stride == C.width {
@@ -4,8 +4,6 @@
 
 package matrix
 
-import "github.com/ziutek/blas"
-
 // Plus returns A + B.
 func PlusBLAS(A, B *Matrix) *Matrix {
 	return Zeros(A.height, A.width).PlusBLAS(A, B)
@@ -16,13 +14,13 @@ func (A *Matrix) AddBLAS(B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width {
-		blas.Daxpy(len(A.data), 1.0, B.data, 1, A.data, 1)
+		backend.Daxpy(len(A.data), 1.0, B.data, 1, A.data, 1)
 		return A
 	}
 
 	// Submatrices.
 	for i := 0; i < A.height; i++ {
-		blas.Daxpy(A.width, 1.0, B.Row(i), 1, A.Row(i), 1)
+		backend.Daxpy(A.width, 1.0, B.Row(i), 1, A.Row(i), 1)
 	}
 
 	return A
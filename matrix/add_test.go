@@ -7,7 +7,6 @@ package matrix
 import (
 	"math/rand"
 	"testing"
-	"time"
 )
 
 func TestPlus(t *testing.T) {
@@ -70,10 +69,10 @@ func TestPlusSubMatrix(t *testing.T) {
 
 func BenchmarkAddBLAS_____1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.AddBLAS(B)
@@ -82,10 +81,10 @@ func BenchmarkAddBLAS_____1024(bench *testing.B) {
 
 func BenchmarkAdd_________1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Add(B)
@@ -94,10 +93,10 @@ func BenchmarkAdd_________1024(bench *testing.B) {
 
 func BenchmarkPlusSBLAS___1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	C := Zeros(n, n)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
@@ -107,10 +106,10 @@ func BenchmarkPlusSBLAS___1024(bench *testing.B) {
 
 func BenchmarkPlusS_______1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	C := Zeros(n, n)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
@@ -120,10 +119,10 @@ func BenchmarkPlusS_______1024(bench *testing.B) {
 
 func BenchmarkPlusBLAS____1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		PlusBLAS(A, B)
@@ -132,10 +131,10 @@ func BenchmarkPlusBLAS____1024(bench *testing.B) {
 
 func BenchmarkPlus________1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Plus(A, B)
@@ -144,10 +143,10 @@ func BenchmarkPlus________1024(bench *testing.B) {
 
 func BenchmarkAddBLAS______256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.AddBLAS(B)
@@ -156,10 +155,10 @@ func BenchmarkAddBLAS______256(bench *testing.B) {
 
 func BenchmarkAdd__________256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Add(B)
@@ -168,10 +167,10 @@ func BenchmarkAdd__________256(bench *testing.B) {
 
 func BenchmarkPlusBLAS_____256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		PlusBLAS(A, B)
@@ -180,10 +179,10 @@ func BenchmarkPlusBLAS_____256(bench *testing.B) {
 
 func BenchmarkPlus_________256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Plus(A, B)
@@ -192,10 +191,10 @@ func BenchmarkPlus_________256(bench *testing.B) {
 
 func BenchmarkAddBLAS_______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.AddBLAS(B)
@@ -204,10 +203,10 @@ func BenchmarkAddBLAS_______32(bench *testing.B) {
 
 func BenchmarkAdd___________32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Add(B)
@@ -216,10 +215,10 @@ func BenchmarkAdd___________32(bench *testing.B) {
 
 func BenchmarkPlusBLAS______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		PlusBLAS(A, B)
@@ -228,10 +227,10 @@ func BenchmarkPlusBLAS______32(bench *testing.B) {
 
 func BenchmarkPlus__________32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Plus(A, B)
@@ -240,10 +239,10 @@ func BenchmarkPlus__________32(bench *testing.B) {
 
 func BenchmarkAddBLASSubM___32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(32, 32, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -254,10 +253,10 @@ func BenchmarkAddBLASSubM___32(bench *testing.B) {
 
 func BenchmarkAddSubM_______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -268,10 +267,10 @@ func BenchmarkAddSubM_______32(bench *testing.B) {
 
 func BenchmarkPlusBLASSubM__32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -282,10 +281,10 @@ func BenchmarkPlusBLASSubM__32(bench *testing.B) {
 
 func BenchmarkPlusSubM______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -293,3 +292,32 @@ func BenchmarkPlusSubM______32(bench *testing.B) {
 		Plus(A, B)
     }
 }
+
+func BenchmarkAddSerial___1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	SetParallelism(1)
+	defer SetParallelism(0)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		A.Add(B)
+	}
+}
+
+func BenchmarkPlusSSerial_1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	C := Zeros(n, n)
+	SetParallelism(1)
+	defer SetParallelism(0)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		C.Plus(A, B)
+	}
+}
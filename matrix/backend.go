@@ -0,0 +1,232 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Backend provides the BLAS routines used by the *BLAS functions and the
+// other routines that work on rows of a matrix.
+//
+// The methods follow the reference BLAS, except that matrices are stored
+// row-major: element (i, j) of a matrix with leading dimension lda is
+// a[i*lda+j]. A transposed argument is stored as its transpose, so for
+// transA the m x k matrix A is stored as a k x m matrix.
+//
+// The package matrix/gonumblas adapts any gonum blas.Float64 implementation,
+// which makes it possible to use OpenBLAS or another optimised library.
+type Backend interface {
+	// Daxpy calculates y = y + α * x.
+	Daxpy(n int, α float64, x []float64, incx int, y []float64, incy int)
+
+	// Ddot returns x · y.
+	Ddot(n int, x []float64, incx int, y []float64, incy int) float64
+
+	// Dscal calculates x = α * x.
+	Dscal(n int, α float64, x []float64, incx int)
+
+	// Dswap exchanges the elements of x and y.
+	Dswap(n int, x []float64, incx int, y []float64, incy int)
+
+	// Dgemv calculates y = α * A * x + β * y for an m x n matrix A, or
+	// y = α * A' * x + β * y if trans is true.
+	Dgemv(trans bool, m, n int, α float64, a []float64, lda int,
+		x []float64, incx int, β float64, y []float64, incy int)
+
+	// Dger calculates A = A + α * x * y' for an m x n matrix A.
+	Dger(m, n int, α float64, x []float64, incx int, y []float64, incy int,
+		a []float64, lda int)
+
+	// Dgemm calculates C = α * op(A) * op(B) + β * C for an m x n matrix C,
+	// where op(X) is X' if the corresponding trans flag is true and X
+	// otherwise.
+	Dgemm(transA, transB bool, m, n, k int, α float64, a []float64, lda int,
+		b []float64, ldb int, β float64, c []float64, ldc int)
+}
+
+// backend is the Backend in use.
+var backend Backend = Native{}
+
+// SetBackend changes the Backend used by the package. It must not be called
+// while other goroutines use the package.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = Native{}
+	}
+	backend = b
+}
+
+// CurrentBackend returns the Backend used by the package.
+func CurrentBackend() Backend {
+	return backend
+}
+
+// Native is the default Backend. It is written in Go and uses the SIMD
+// kernels of this package for vectors with unit increments, so it needs
+// neither cgo nor other packages.
+type Native struct{}
+
+func (Native) Daxpy(n int, α float64, x []float64, incx int, y []float64, incy int) {
+	if n <= 0 || α == 0 {
+		return
+	}
+	if incx == 1 && incy == 1 {
+		axpy(α, x[:n], y[:n])
+		return
+	}
+	ix, iy := start(n, incx), start(n, incy)
+	for i := 0; i < n; i++ {
+		y[iy] += α * x[ix]
+		ix += incx
+		iy += incy
+	}
+}
+
+func (Native) Ddot(n int, x []float64, incx int, y []float64, incy int) float64 {
+	if n <= 0 {
+		return 0
+	}
+	if incx == 1 && incy == 1 {
+		return dot(x[:n], y[:n])
+	}
+	sum := 0.0
+	ix, iy := start(n, incx), start(n, incy)
+	for i := 0; i < n; i++ {
+		sum += x[ix] * y[iy]
+		ix += incx
+		iy += incy
+	}
+	return sum
+}
+
+func (Native) Dscal(n int, α float64, x []float64, incx int) {
+	if incx <= 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		x[i * incx] *= α
+	}
+}
+
+func (Native) Dswap(n int, x []float64, incx int, y []float64, incy int) {
+	ix, iy := start(n, incx), start(n, incy)
+	for i := 0; i < n; i++ {
+		x[ix], y[iy] = y[iy], x[ix]
+		ix += incx
+		iy += incy
+	}
+}
+
+func (r Native) Dgemv(trans bool, m, n int, α float64, a []float64, lda int,
+	x []float64, incx int, β float64, y []float64, incy int) {
+
+	leny := m
+	if trans {
+		leny = n
+	}
+	scale(leny, β, y, incy)
+	if α == 0 {
+		return
+	}
+
+	if !trans {
+		iy := start(m, incy)
+		for i := 0; i < m; i++ {
+			y[iy] += α * r.Ddot(n, a[i * lda:], 1, x, incx)
+			iy += incy
+		}
+		return
+	}
+
+	ix := start(m, incx)
+	for i := 0; i < m; i++ {
+		r.Daxpy(n, α * x[ix], a[i * lda:], 1, y, incy)
+		ix += incx
+	}
+}
+
+func (r Native) Dger(m, n int, α float64, x []float64, incx int, y []float64, incy int,
+	a []float64, lda int) {
+
+	ix := start(m, incx)
+	for i := 0; i < m; i++ {
+		r.Daxpy(n, α * x[ix], y, incy, a[i * lda:], 1)
+		ix += incx
+	}
+}
+
+func (r Native) Dgemm(transA, transB bool, m, n, k int, α float64, a []float64, lda int,
+	b []float64, ldb int, β float64, c []float64, ldc int) {
+
+	for i := 0; i < m; i++ {
+		scale(n, β, c[i * ldc:], 1)
+	}
+	if α == 0 || k == 0 {
+		return
+	}
+
+	switch {
+	case !transA && !transB && α == 1:
+		C := &Matrix{m, n, ldc, c}
+		C.MulAddSIMD(&Matrix{m, k, lda, a}, &Matrix{k, n, ldb, b})
+
+	case !transA && !transB:
+		for i := 0; i < m; i++ {
+			for p := 0; p < k; p++ {
+				r.Daxpy(n, α * a[i * lda + p], b[p * ldb:], 1, c[i * ldc:], 1)
+			}
+		}
+
+	case transA && !transB:
+		// A is stored as k x m, so row p of A' * B is a sum of outer products.
+		for p := 0; p < k; p++ {
+			for i := 0; i < m; i++ {
+				r.Daxpy(n, α * a[p * lda + i], b[p * ldb:], 1, c[i * ldc:], 1)
+			}
+		}
+
+	case !transA && transB:
+		// B is stored as n x k, so C(i, j) is the dot product of two rows.
+		for i := 0; i < m; i++ {
+			ci := c[i * ldc : i * ldc + n]
+			for j := range ci {
+				ci[j] += α * r.Ddot(k, a[i * lda:], 1, b[j * ldb:], 1)
+			}
+		}
+
+	default:
+		for i := 0; i < m; i++ {
+			ci := c[i * ldc : i * ldc + n]
+			for j := range ci {
+				ci[j] += α * r.Ddot(k, a[i:], lda, b[j * ldb:], 1)
+			}
+		}
+	}
+}
+
+// start returns the index of the first element of a vector of n elements with
+// increment inc, which is at the end of the slice for negative increments.
+func start(n, inc int) int {
+	if inc < 0 {
+		return (1 - n) * inc
+	}
+	return 0
+}
+
+// scale calculates x = β * x, where β == 0 clears x even if it contains NaNs.
+func scale(n int, β float64, x []float64, incx int) {
+	switch {
+	case β == 1:
+	case β == 0:
+		ix := start(n, incx)
+		for i := 0; i < n; i++ {
+			x[ix] = 0
+			ix += incx
+		}
+	default:
+		ix := start(n, incx)
+		for i := 0; i < n; i++ {
+			x[ix] *= β
+			ix += incx
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNativeStrided(t *testing.T) {
+	var b Native
+	x := []float64{1, 2, 3, 4, 5, 6}
+	y := []float64{1, 1, 1, 1, 1, 1}
+
+	// x(0), x(2), x(4) against y(2), y(1), y(0).
+	if d := b.Ddot(3, x, 2, y[:3], -1); d != 9 {
+		t.Errorf("Ddot = %v, want 9", d)
+	}
+	b.Daxpy(3, 2, x, 2, y, -1)
+	want := []float64{11, 7, 3, 1, 1, 1}
+	for i := range y {
+		if y[i] != want[i] {
+			t.Fatalf("Daxpy: y = %v, want %v", y, want)
+		}
+	}
+	b.Dswap(2, x, 3, y, 1)
+	if x[0] != 11 || x[3] != 7 || y[0] != 1 || y[1] != 4 {
+		t.Errorf("Dswap: x = %v, y = %v", x, y)
+	}
+	b.Dscal(3, 0.5, x, 2)
+	if x[0] != 5.5 || x[2] != 1.5 || x[4] != 2.5 || x[1] != 2 {
+		t.Errorf("Dscal: x = %v", x)
+	}
+}
+
+func TestNativeDgemm(t *testing.T) {
+	var b Native
+	m, n, k := 13, 9, 7
+	A := randomMatrix(m, k)
+	B := randomMatrix(k, n)
+	AT := Transpose(A)
+	BT := Transpose(B)
+	C0 := randomMatrix(m, n)
+
+	// C = 2 * A * B - 0.5 * C0
+	want := MulNaive(A, B)
+	want.Scale(2)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			want.Set(i, j, want.At(i, j) - 0.5 * C0.At(i, j))
+		}
+	}
+
+	for _, trans := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		a, lda := A.data, A.stride
+		if trans[0] {
+			a, lda = AT.data, AT.stride
+		}
+		bb, ldb := B.data, B.stride
+		if trans[1] {
+			bb, ldb = BT.data, BT.stride
+		}
+		C := Zeros(m, n)
+		C.Copy(C0)
+		b.Dgemm(trans[0], trans[1], m, n, k, 2, a, lda, bb, ldb, -0.5, C.data, C.stride)
+		if !equal(want, C, ε, t) {
+			t.Fatalf("transA = %v, transB = %v", trans[0], trans[1])
+		}
+	}
+}
+
+func TestNativeDgemmClearsNaN(t *testing.T) {
+	C := New(1, 1, []float64{math.NaN()})
+	Native{}.Dgemm(false, false, 1, 1, 1, 1, []float64{2}, 1, []float64{3}, 1, 0, C.data, 1)
+	if C.At(0, 0) != 6 {
+		t.Errorf("C = %v, want 6", C.At(0, 0))
+	}
+}
+
+func TestNativeDgemv(t *testing.T) {
+	var b Native
+	A := randomMatrix(11, 6)
+	x := Vector(randomMatrix(1, 6).Row(0))
+	y := make(Vector, 11)
+	b.Dgemv(false, 11, 6, 1, A.data, A.stride, x, 1, 0, y, 1)
+	want := MulNaive(A, New(6, 1, x))
+	if !equal(want, New(11, 1, y), ε, t) {
+		t.FailNow()
+	}
+
+	z := make(Vector, 6)
+	b.Dgemv(true, 11, 6, 1, A.data, A.stride, y, 1, 0, z, 1)
+	want = MulNaive(Transpose(A), New(11, 1, y))
+	if !equal(want, New(6, 1, z), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestSetBackend(t *testing.T) {
+	SetBackend(nil)
+	if _, ok := CurrentBackend().(Native); !ok {
+		t.Errorf("SetBackend(nil) does not restore the native backend")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Band is an m x n band matrix with kl subdiagonals and ku superdiagonals.
+//
+// Only the band is stored: row i holds the elements in columns i-kl to i+ku,
+// which takes (kl + ku + 1) elements per row instead of n.
+type Band struct {
+	height, width int
+	kl, ku        int
+	data          []float64
+}
+
+// NewBand returns a zero-filled m x n band matrix with kl subdiagonals and ku
+// superdiagonals.
+func NewBand(m, n, kl, ku int) *Band {
+	return &Band{m, n, kl, ku, make([]float64, m * (kl + ku + 1))}
+}
+
+// BandFrom returns the band of A with kl subdiagonals and ku superdiagonals.
+func BandFrom(A *Matrix, kl, ku int) *Band {
+	B := NewBand(A.height, A.width, kl, ku)
+	for i := 0; i < B.height; i++ {
+		j, Bi := B.row(i)
+		copy(Bi, A.Row(i)[j:])
+	}
+	return B
+}
+
+// Dense returns B as a full matrix.
+func (B *Band) Dense() *Matrix {
+	A := Zeros(B.height, B.width)
+	for i := 0; i < B.height; i++ {
+		j, Bi := B.row(i)
+		copy(A.Row(i)[j:], Bi)
+	}
+	return A
+}
+
+// row returns the part of row i inside the band and the column it starts at.
+func (B *Band) row(i int) (int, []float64) {
+	first := i - B.kl
+	last := i + B.ku + 1
+	if last > B.width {
+		last = B.width
+	}
+	offset := i * (B.kl + B.ku + 1) - first
+	if first < 0 {
+		first = 0
+	}
+	if first > B.width {
+		first = B.width
+	}
+	if last < first {
+		last = first
+	}
+	return first, B.data[offset + first : offset + last]
+}
+
+// Rows returns the number of rows.
+func (B *Band) Rows() int {
+	return B.height
+}
+
+// Cols returns the number of columns.
+func (B *Band) Cols() int {
+	return B.width
+}
+
+// Bandwidth returns the number of subdiagonals and superdiagonals.
+func (B *Band) Bandwidth() (kl, ku int) {
+	return B.kl, B.ku
+}
+
+// At returns the value of the matrix at row i and column j.
+func (B *Band) At(i, j int) float64 {
+	if i < 0 || i >= B.height || j < 0 || j >= B.width {
+		panic("matrix.Band.At: index out of range.")
+	}
+	if j < i - B.kl || j > i + B.ku {
+		return 0
+	}
+	return B.data[i * (B.kl + B.ku + 1) + j - i + B.kl]
+}
+
+// Set changes the value of the matrix at row i and column j, which must be
+// inside the band.
+func (B *Band) Set(i, j int, v float64) {
+	if i < 0 || i >= B.height || j < 0 || j >= B.width {
+		panic("matrix.Band.Set: index out of range.")
+	}
+	if j < i - B.kl || j > i + B.ku {
+		panic("matrix.Band.Set: element is outside the band.")
+	}
+	B.data[i * (B.kl + B.ku + 1) + j - i + B.kl] = v
+}
+
+// MulBand returns A * B for a band matrix A.
+func MulBand(A *Band, B *Matrix) *Matrix {
+	return Zeros(A.height, B.width).MulBand(A, B)
+}
+
+// MulBand calculates C = A * B for a band matrix A and returns C.
+func (C *Matrix) MulBand(A *Band, B *Matrix) *Matrix {
+	for i := 0; i < A.height; i++ {
+		Ci := C.Row(i)
+		for k := range Ci {
+			Ci[k] = 0
+		}
+		j, Ai := A.row(i)
+		for k, aij := range Ai {
+			// Ci += aij * Bj
+			backend.Daxpy(C.width, aij, B.Row(j + k), 1, Ci, 1)
+		}
+	}
+	return C
+}
+
+// MulVecBand returns A * x for a band matrix A.
+func MulVecBand(A *Band, x Vector) Vector {
+	return make(Vector, A.height).MulVecBand(A, x)
+}
+
+// MulVecBand calculates y = A * x for a band matrix A and returns y.
+func (y Vector) MulVecBand(A *Band, x Vector) Vector {
+	for i := range y {
+		j, Ai := A.row(i)
+		y[i] = backend.Ddot(len(Ai), Ai, 1, x[j:], 1)
+	}
+	return y
+}
@@ -0,0 +1,58 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"testing"
+)
+
+func TestBandDense(t *testing.T) {
+	A := New(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	B := BandFrom(A, 1, 0).Dense()
+
+	correct := New(3, 4, []float64{1, 0, 0, 0, 5, 6, 0, 0, 0, 10, 11, 0})
+	if !equal(B, correct, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestBandIndex(t *testing.T) {
+	B := BandFrom(New(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}), 1, 1)
+	if B.At(1, 2) != 7 || B.At(0, 3) != 0 {
+		t.Errorf("At(1, 2) = %v, At(0, 3) = %v", B.At(1, 2), B.At(0, 3))
+	}
+	for _, ij := range [][2]int{{0, -1}, {1, 4}, {2, 4}, {-1, 0}, {3, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At(%d, %d) did not panic", ij[0], ij[1])
+				}
+			}()
+			B.At(ij[0], ij[1])
+		}()
+	}
+}
+
+func TestMulBand(t *testing.T) {
+	for _, size := range [][4]int{{40, 40, 2, 3}, {50, 30, 0, 4}, {30, 50, 5, 0}, {20, 20, 30, 30}} {
+		m, n, kl, ku := size[0], size[1], size[2], size[3]
+		A := BandFrom(randomMatrix(m, n), kl, ku)
+		B := randomMatrix(n, 25)
+		C := MulNaive(A.Dense(), B)
+		D := MulBand(A, B)
+
+		if !equal(C, D, ε, t) {
+			t.Fatalf("Wrong result for %d x %d, kl = %d, ku = %d", m, n, kl, ku)
+		}
+
+		x := randomMatrix(n, 1)
+		C = MulNaive(A.Dense(), x)
+		y := MulVecBand(A, Vector(x.data))
+
+		if !equal(C, New(m, 1, y), ε, t) {
+			t.Fatalf("Wrong vector result for %d x %d, kl = %d, ku = %d", m, n, kl, ku)
+		}
+	}
+}
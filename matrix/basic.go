@@ -49,6 +49,19 @@ func (A *Matrix) Clear() {
 
 // Copy the contents of B to A.
 func (A *Matrix) Copy(B *Matrix) {
+	// Only matrices of the same size are split, a smaller B is copied as
+	// far as it goes.
+	if parallel(A) && B.height == A.height && B.width == A.width {
+		a, b := *A, *B
+		inBands(a.height, func(i0, i1 int) {
+			a.band(i0, i1).copy(b.band(i0, i1))
+		})
+		return
+	}
+	A.copy(B)
+}
+
+func (A *Matrix) copy(B *Matrix) {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width {
@@ -109,16 +122,69 @@ func (A *Matrix) RowVectors() [][]float64 {
 // Transpose returns the matrix transpose of A.
 func Transpose(A *Matrix) *Matrix {
 	B := Zeros(A.width, A.height)
-	offset := 0
-
-	for i := range B.data {
-		B.data[i] = A.data[offset]
 
-		offset += A.stride
-		if offset >= len(A.data) {
-			offset = offset % len(A.data) + 1
+	for i := 0; i < A.height; i++ {
+		for j, aij := range A.Row(i) {
+			B.data[j * B.stride + i] = aij
 		}
 	}
 
 	return B
 }
+
+// TransposeInPlace transposes the square matrix A without allocating a copy
+// and returns A.
+//
+// The matrix is split recursively into blocks until they fit in the cache, so
+// the transpose is efficient for every cache size (cache-oblivious).
+func (A *Matrix) TransposeInPlace() *Matrix {
+	if A.height != A.width {
+		panic("matrix.TransposeInPlace: matrix is not square.")
+	}
+	transposeDiagonal(A.data, A.stride, 0, A.height)
+	return A
+}
+
+// transposeBlock is the size below which blocks are transposed directly.
+const transposeBlock = 16
+
+// transposeDiagonal transposes the n x n block at row i and column i.
+func transposeDiagonal(data []float64, stride, i, n int) {
+	if n <= transposeBlock {
+		for r := i; r < i + n; r++ {
+			for c := r + 1; c < i + n; c++ {
+				data[r * stride + c], data[c * stride + r] = data[c * stride + r], data[r * stride + c]
+			}
+		}
+		return
+	}
+
+	h := n / 2
+	transposeDiagonal(data, stride, i, h)
+	transposeDiagonal(data, stride, i + h, n - h)
+	transposeSwap(data, stride, i + h, i, n - h, h)
+}
+
+// transposeSwap swaps the m x n block at row r and column c with the
+// transpose of its mirror image on the other side of the diagonal.
+func transposeSwap(data []float64, stride, r, c, m, n int) {
+	if m <= transposeBlock && n <= transposeBlock {
+		for i := r; i < r + m; i++ {
+			for j := c; j < c + n; j++ {
+				data[i * stride + j], data[j * stride + i] = data[j * stride + i], data[i * stride + j]
+			}
+		}
+		return
+	}
+
+	// Split the largest dimension.
+	if m >= n {
+		h := m / 2
+		transposeSwap(data, stride, r, c, h, n)
+		transposeSwap(data, stride, r + h, c, m - h, n)
+	} else {
+		h := n / 2
+		transposeSwap(data, stride, r, c, m, h)
+		transposeSwap(data, stride, r, c + h, m, n - h)
+	}
+}
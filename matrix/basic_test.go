@@ -7,7 +7,6 @@ package matrix
 import (
 	"testing"
 	"math"
-	"math/rand"
 )
 
 func TestSubMatrix(t *testing.T) {
@@ -58,18 +57,35 @@ func TestTranspose(t *testing.T) {
 	}
 }
 
-func randomMatrix(m, n int) (A *Matrix) {
-	A = Zeros(m, n)
-	A.randomize()
-	return A
+func TestTransposeSubMatrix(t *testing.T) {
+	A := randomMatrix(6, 7).SubMatrix(1, 2, 3, 4)
+	B := Transpose(A)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			if A.At(i, j) != B.At(j, i) {
+				t.Fatalf("Element A(%d,%d) should be the same as B(%d, %d)", i, j, j, i)
+			}
+		}
+	}
 }
 
-func (A *Matrix) randomize() {
-	for i := range A.data {
-		A.data[i] = rand.Float64()
+func TestTransposeInPlace(t *testing.T) {
+	for _, n := range []int{1, 5, 16, 33, 100} {
+		A := randomMatrix(n + 3, n + 3).SubMatrix(2, 1, n, n)
+		B := Transpose(A)
+		A.TransposeInPlace()
+
+		if !equal(A, B, 0, t) {
+			t.Fatalf("Wrong in-place transpose for n = %d", n)
+		}
 	}
 }
 
+func randomMatrix(m, n int) *Matrix {
+	return RandomUniform(m, n, nil)
+}
+
 func equal(A, B *Matrix, ε float64, t *testing.T) bool {
 	if A.height != B.height || A.width != B.width {
 		t.Fatalf("Wrong result: different size A: %d x %d \n A: %d x %d \n", A.height, A.width, B.height, B.width)
@@ -0,0 +1,209 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/harrydb/go/matrix"
+)
+
+// DefaultBlockSize is the block size used when Cluster.BlockSize is 0.
+const DefaultBlockSize = 512
+
+// Cluster is a coordinator for a set of workers.
+//
+// A Cluster can be used by multiple goroutines at the same time.
+type Cluster struct {
+	// BlockSize is the number of rows and columns of the blocks that A, B
+	// and C are split into. Blocks at the bottom and right edge may be
+	// smaller.
+	BlockSize int
+
+	// Kernel is the routine the workers use to multiply blocks.
+	Kernel Kernel
+
+	workers []*rpc.Client
+}
+
+// NewCluster returns a Cluster that uses the given connections to workers.
+func NewCluster(workers ...*rpc.Client) *Cluster {
+	return &Cluster{workers: workers}
+}
+
+// Dial connects to the workers at the given addresses.
+func Dial(network string, addrs ...string) (*Cluster, error) {
+	c := NewCluster()
+	for _, addr := range addrs {
+		client, err := rpc.Dial(network, addr)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.workers = append(c.workers, client)
+	}
+	return c, nil
+}
+
+// NewLocal returns a Cluster of n workers that run in the current process.
+// The workers are connected through in-memory pipes, so everything except
+// the network itself is exercised.
+func NewLocal(n int) *Cluster {
+	c := NewCluster()
+	for i := 0; i < n; i++ {
+		s := rpc.NewServer()
+		s.RegisterName("Worker", NewWorker())
+		client, server := net.Pipe()
+		go s.ServeConn(server)
+		c.workers = append(c.workers, rpc.NewClient(client))
+	}
+	return c
+}
+
+// Close closes the connections to the workers.
+func (c *Cluster) Close() error {
+	var err error
+	for _, w := range c.workers {
+		if e := w.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Mul returns A * B.
+func (c *Cluster) Mul(A, B *matrix.Matrix) (*matrix.Matrix, error) {
+	C := matrix.Zeros(A.Rows(), B.Cols())
+	if err := c.MulInto(C, A, B); err != nil {
+		return nil, err
+	}
+	return C, nil
+}
+
+// span is a range of rows or columns.
+type span struct {
+	start, size int
+}
+
+// task is a block of C owned by one worker.
+type task struct {
+	id   int // Issued by the worker, 0 until the block is created.
+	i, j span
+}
+
+// MulInto calculates C = A * B.
+//
+// C is only written after all blocks have been calculated. If an error is
+// returned C may be partially written.
+//
+// The blocks of A and B are sent from the matrices in memory, so the
+// coordinator needs memory for all of A, B and C. Only the workers are
+// relieved of it.
+func (c *Cluster) MulInto(C, A, B *matrix.Matrix) error {
+	if A.Cols() != B.Rows() || C.Rows() != A.Rows() || C.Cols() != B.Cols() {
+		panic("dist.Cluster.MulInto: dimensions do not match.")
+	}
+	if len(c.workers) == 0 {
+		panic("dist.Cluster.MulInto: no workers.")
+	}
+	bs := c.BlockSize
+	if bs <= 0 {
+		bs = DefaultBlockSize
+	}
+	rows := split(A.Rows(), bs)
+	cols := split(B.Cols(), bs)
+	inner := split(A.Cols(), bs)
+
+	// Deal out the blocks of C round robin.
+	tasks := make([][]task, len(c.workers))
+	for bi, i := range rows {
+		for bj, j := range cols {
+			w := (bi * len(cols) + bj) % len(c.workers)
+			tasks[w] = append(tasks[w], task{i: i, j: j})
+		}
+	}
+
+	// The workers issue the block IDs, so that coordinators sharing a worker
+	// cannot use each other's blocks.
+	err := c.each(tasks, func(w *rpc.Client, t *task) error {
+		return w.Call("Worker.Init", &InitArgs{t.i.size, t.j.size, c.Kernel}, &t.id)
+	})
+
+	// Step k adds A(i, k) * B(k, j) to every block C(i, j).
+	for _, k := range inner {
+		if err != nil {
+			break
+		}
+		err = c.each(tasks, func(w *rpc.Client, t *task) error {
+			args := &UpdateArgs{
+				ID: t.id,
+				A:  NewBlock(A.SubMatrix(t.i.start, k.start, t.i.size, k.size)),
+				B:  NewBlock(B.SubMatrix(k.start, t.j.start, k.size, t.j.size)),
+			}
+			var reply int
+			return w.Call("Worker.Update", args, &reply)
+		})
+	}
+
+	// Fetching also frees the blocks on the workers, so it is done even after
+	// an error, for every block that was created.
+	failed := err != nil
+	ferr := c.each(tasks, func(w *rpc.Client, t *task) error {
+		if t.id == 0 {
+			return nil
+		}
+		var b Block
+		if err := w.Call("Worker.Fetch", t.id, &b); err != nil {
+			return err
+		}
+		if !failed {
+			C.SubMatrix(t.i.start, t.j.start, t.i.size, t.j.size).Copy(b.Matrix())
+		}
+		return nil
+	})
+	if err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// each calls f for every task, the tasks of different workers in parallel.
+// It returns the first error, but calls f for all tasks.
+func (c *Cluster) each(tasks [][]task, f func(*rpc.Client, *task) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(tasks))
+	for w, ts := range tasks {
+		wg.Add(1)
+		go func(w int, ts []task) {
+			defer wg.Done()
+			for i := range ts {
+				if err := f(c.workers[w], &ts[i]); err != nil && errs[w] == nil {
+					errs[w] = err
+				}
+			}
+		}(w, ts)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// split divides n rows or columns into spans of at most size.
+func split(n, size int) []span {
+	var spans []span
+	for start := 0; start < n; start += size {
+		if start + size > n {
+			size = n - start
+		}
+		spans = append(spans, span{start, size})
+	}
+	return spans
+}
@@ -0,0 +1,62 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dist multiplies matrices on a cluster of worker processes.
+//
+// The product C = A * B is split into blocks of C, and each block is owned by
+// one worker. Like SUMMA, the multiplication proceeds in steps: in step k the
+// coordinator sends block column k of A and block row k of B to the workers,
+// which add the product of their two blocks to their block of C. The blocks
+// of C stay on the workers until the last step, so a worker only needs memory
+// for its blocks of C and one pair of blocks of A and B. The coordinator
+// still holds A, B and C in full.
+//
+// Workers are net/rpc servers, started with Serve:
+//
+//	l, err := net.Listen("tcp", ":7000")
+//	...
+//	dist.Serve(l)
+//
+// The coordinator connects to them with Dial and multiplies with Mul:
+//
+//	c, err := dist.Dial("tcp", "node1:7000", "node2:7000")
+//	...
+//	C, err := c.Mul(A, B)
+//
+// NewLocal starts workers in the current process, which is useful for tests.
+package dist
+
+import "github.com/harrydb/go/matrix"
+
+// Block is a dense matrix in a form that can be sent over the network.
+type Block struct {
+	Rows, Cols int
+	Data       []float64
+}
+
+// NewBlock returns a copy of A as a Block.
+func NewBlock(A *matrix.Matrix) Block {
+	b := Block{A.Rows(), A.Cols(), make([]float64, A.Rows() * A.Cols())}
+	for i := 0; i < b.Rows; i++ {
+		copy(b.Data[i * b.Cols:], A.Row(i))
+	}
+	return b
+}
+
+// Matrix returns the block as a matrix that shares its data.
+func (b Block) Matrix() *matrix.Matrix {
+	return matrix.New(b.Rows, b.Cols, b.Data)
+}
+
+// Kernel selects the routine a worker uses to multiply two blocks.
+type Kernel int
+
+const (
+	// BLAS uses MulAddBLAS, which suits any block size.
+	BLAS Kernel = iota
+
+	// Douglas uses MulDouglas for square blocks, which is faster for blocks
+	// of 80 x 80 and larger, and MulAddBLAS for the other blocks.
+	Douglas
+)
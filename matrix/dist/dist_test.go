@@ -0,0 +1,198 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math/rand"
+	"net"
+	"net/rpc"
+	"sync"
+	"testing"
+
+	"github.com/harrydb/go/matrix"
+	"github.com/harrydb/go/matrix/matrixtest"
+)
+
+var tol = matrixtest.Tolerance{Abs: 10e-12}
+
+var rnd = rand.New(rand.NewSource(1))
+
+func randomMatrix(m, n int) *matrix.Matrix {
+	return matrix.RandomNormal(m, n, rnd)
+}
+
+func newWorkerClient() *rpc.Client {
+	s := rpc.NewServer()
+	s.RegisterName("Worker", NewWorker())
+	client, server := net.Pipe()
+	go s.ServeConn(server)
+	return rpc.NewClient(client)
+}
+
+func TestMulLocal(t *testing.T) {
+	c := NewLocal(3)
+	defer c.Close()
+	c.BlockSize = 64
+
+	A := randomMatrix(150, 130)
+	B := randomMatrix(130, 170)
+	C, err := c.Mul(A, B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrixtest.AssertEqual(t, C, matrix.MulNaive(A, B), tol)
+}
+
+func TestMulDouglasKernel(t *testing.T) {
+	c := NewLocal(2)
+	defer c.Close()
+	c.BlockSize = 160
+	c.Kernel = Douglas
+
+	// The last block row and column are not square.
+	A := randomMatrix(400, 400)
+	B := randomMatrix(400, 400)
+	C, err := c.Mul(A, B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrixtest.AssertEqual(t, C, matrix.MulNaive(A, B), tol)
+}
+
+func TestMulIntoSubMatrix(t *testing.T) {
+	c := NewLocal(4)
+	defer c.Close()
+	c.BlockSize = 16
+
+	A := randomMatrix(60, 60).SubMatrix(5, 7, 40, 33)
+	B := randomMatrix(60, 60).SubMatrix(2, 3, 33, 45)
+	C := matrix.Zeros(50, 50)
+	if err := c.MulInto(C.SubMatrix(1, 2, 40, 45), A, B); err != nil {
+		t.Fatal(err)
+	}
+	D := matrix.Zeros(50, 50)
+	D.SubMatrix(1, 2, 40, 45).MulNaive(A, B)
+	matrixtest.AssertEqual(t, C, D, tol)
+}
+
+func TestMulTCP(t *testing.T) {
+	var addrs []string
+	for i := 0; i < 2; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skip("cannot listen on loopback:", err)
+		}
+		defer l.Close()
+		go Serve(l)
+		addrs = append(addrs, l.Addr().String())
+	}
+
+	c, err := Dial("tcp", addrs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.BlockSize = 32
+
+	A := randomMatrix(70, 50)
+	B := randomMatrix(50, 90)
+	C, err := c.Mul(A, B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrixtest.AssertEqual(t, C, matrix.MulNaive(A, B), tol)
+}
+
+func TestWorkerErrors(t *testing.T) {
+	w := newWorkerClient()
+	defer w.Close()
+
+	var reply int
+	if err := w.Call("Worker.Update", &UpdateArgs{ID: 1}, &reply); err == nil {
+		t.Error("Update of an unknown block succeeded")
+	}
+	var id, id2 int
+	if err := w.Call("Worker.Init", &InitArgs{2, 2, BLAS}, &id); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Call("Worker.Init", &InitArgs{2, 2, BLAS}, &id2); err != nil {
+		t.Fatal(err)
+	}
+	if id == 0 || id == id2 {
+		t.Errorf("Init issued IDs %d and %d", id, id2)
+	}
+	if err := w.Call("Worker.Init", &InitArgs{-1, 2, BLAS}, &reply); err == nil {
+		t.Error("Init of a negative size block succeeded")
+	}
+	args := &UpdateArgs{id, NewBlock(randomMatrix(2, 3)), NewBlock(randomMatrix(2, 2))}
+	if err := w.Call("Worker.Update", args, &reply); err == nil {
+		t.Error("Update with mismatched blocks succeeded")
+	}
+	var b Block
+	if err := w.Call("Worker.Fetch", id, &b); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Call("Worker.Fetch", id, &b); err == nil {
+		t.Error("Fetch of a fetched block succeeded")
+	}
+}
+
+// TestSharedWorkers multiplies with several clusters that share their workers
+// at the same time.
+func TestSharedWorkers(t *testing.T) {
+	workers := []*rpc.Client{newWorkerClient(), newWorkerClient()}
+	defer workers[0].Close()
+	defer workers[1].Close()
+
+	const n = 4
+	As, Bs, Cs := make([]*matrix.Matrix, n), make([]*matrix.Matrix, n), make([]*matrix.Matrix, n)
+	for i := range As {
+		As[i] = randomMatrix(40, 30)
+		Bs[i] = randomMatrix(30, 50)
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := NewCluster(workers...)
+			c.BlockSize = 8
+			Cs[i], errs[i] = c.Mul(As[i], Bs[i])
+		}(i)
+	}
+	wg.Wait()
+	for i := range Cs {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		matrixtest.AssertEqual(t, Cs[i], matrix.MulNaive(As[i], Bs[i]), tol)
+	}
+}
+
+// TestInitError checks that a failed multiplication does not fetch blocks it
+// did not create.
+func TestInitError(t *testing.T) {
+	w := newWorkerClient()
+	defer w.Close()
+	var id int
+	if err := w.Call("Worker.Init", &InitArgs{2, 2, BLAS}, &id); err != nil {
+		t.Fatal(err)
+	}
+
+	// The block above belongs to another coordinator. The second connection
+	// of the cluster is closed, so the Init of half its blocks fails.
+	closed := newWorkerClient()
+	closed.Close()
+	c := NewCluster(w, closed)
+	c.BlockSize = 2
+	if _, err := c.Mul(randomMatrix(4, 4), randomMatrix(4, 4)); err == nil {
+		t.Fatal("Mul with a closed connection succeeded")
+	}
+	var b Block
+	if err := w.Call("Worker.Fetch", id, &b); err != nil {
+		t.Error("block of another coordinator was removed:", err)
+	}
+}
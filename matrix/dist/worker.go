@@ -0,0 +1,126 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/harrydb/go/matrix"
+)
+
+// InitArgs are the arguments of Worker.Init.
+type InitArgs struct {
+	Rows, Cols int
+	Kernel     Kernel
+}
+
+// UpdateArgs are the arguments of Worker.Update.
+type UpdateArgs struct {
+	ID   int
+	A, B Block
+}
+
+// Worker is the net/rpc service that holds blocks of C.
+//
+// Its methods are called by the coordinator, not directly.
+type Worker struct {
+	mu     sync.Mutex
+	blocks map[int]*workerBlock
+	nextID int
+}
+
+type workerBlock struct {
+	sync.Mutex
+	C      *matrix.Matrix
+	kernel Kernel
+	w      *matrix.Workspace
+	tmp    *matrix.Matrix
+}
+
+// NewWorker returns a Worker without blocks.
+func NewWorker() *Worker {
+	return &Worker{blocks: make(map[int]*workerBlock)}
+}
+
+// Serve registers a new Worker and serves connections from l until l is
+// closed.
+func Serve(l net.Listener) {
+	s := rpc.NewServer()
+	s.RegisterName("Worker", NewWorker())
+	s.Accept(l)
+}
+
+// Init creates a zero block of C, and replies with its ID. IDs are never
+// reused, and never 0.
+func (wk *Worker) Init(args *InitArgs, reply *int) error {
+	if args.Rows < 0 || args.Cols < 0 {
+		return fmt.Errorf("dist: cannot create %dx%d block", args.Rows, args.Cols)
+	}
+	wk.mu.Lock()
+	defer wk.mu.Unlock()
+	wk.nextID++
+	wk.blocks[wk.nextID] = &workerBlock{
+		C:      matrix.Zeros(args.Rows, args.Cols),
+		kernel: args.Kernel,
+	}
+	*reply = wk.nextID
+	return nil
+}
+
+// Update adds A * B to a block of C.
+func (wk *Worker) Update(args *UpdateArgs, reply *int) error {
+	b, err := wk.block(args.ID)
+	if err != nil {
+		return err
+	}
+	A, B := args.A.Matrix(), args.B.Matrix()
+	if A.Rows() != b.C.Rows() || B.Cols() != b.C.Cols() || A.Cols() != B.Rows() {
+		return fmt.Errorf("dist: cannot add %dx%d * %dx%d to %dx%d block %d",
+			A.Rows(), A.Cols(), B.Rows(), B.Cols(), b.C.Rows(), b.C.Cols(), args.ID)
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	n := A.Rows()
+	if b.kernel == Douglas && A.Cols() == n && B.Cols() == n {
+		if b.tmp == nil {
+			b.tmp = matrix.Zeros(n, n)
+			b.w = matrix.NewWorkspace(n)
+		}
+		b.C.AddBLAS(b.tmp.MulDouglasWorkspace(A, B, b.w))
+		return nil
+	}
+	b.C.MulAddBLAS(A, B)
+	return nil
+}
+
+// Fetch returns a block of C and removes it from the worker.
+func (wk *Worker) Fetch(id int, reply *Block) error {
+	b, err := wk.block(id)
+	if err != nil {
+		return err
+	}
+	wk.mu.Lock()
+	delete(wk.blocks, id)
+	wk.mu.Unlock()
+
+	b.Lock()
+	defer b.Unlock()
+	*reply = NewBlock(b.C)
+	return nil
+}
+
+func (wk *Worker) block(id int) (*workerBlock, error) {
+	wk.mu.Lock()
+	defer wk.mu.Unlock()
+	b, ok := wk.blocks[id]
+	if !ok {
+		return nil, fmt.Errorf("dist: unknown block %d", id)
+	}
+	return b, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import "math"
+
+// Coefficients of the [m/m] Padé approximants to exp(x) and the largest
+// 1-norm for which each approximant is accurate to double precision.
+var (
+	padeCoefficients = map[int][]float64{
+		3:  {120, 60, 12, 1},
+		5:  {30240, 15120, 3360, 420, 30, 1},
+		7:  {17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+		9:  {17643225600, 8821612800, 2075673600, 302702400, 30270240,
+			2162160, 110880, 3960, 90, 1},
+		13: {64764752532480000, 32382376266240000, 7771770303897600,
+			1187353796428800, 129060195264000, 10559470521600, 670442572800,
+			33522128640, 1323241920, 40840800, 960960, 16380, 182, 1},
+	}
+	padeThetas = []struct {
+		m     int
+		theta float64
+	}{
+		{3, 1.495585217958292e-2},
+		{5, 2.539398330063230e-1},
+		{7, 9.504178996162932e-1},
+		{9, 2.097847961257068e0},
+		{13, 5.371920351148152e0},
+	}
+)
+
+// Expm returns the matrix exponential of the square matrix A.
+//
+// The exponential is calculated with the scaling and squaring method: A is
+// scaled by 1/2^s until a Padé approximant of degree 3, 5, 7, 9 or 13 is
+// accurate, and the result is squared s times. Multiplications use Mul, so
+// large matrices are multiplied with one of the Strassen variants.
+//
+//	Original paper:
+//	N. J. Higham, 2005.
+//	The Scaling and Squaring Method for the Matrix Exponential Revisited.
+//	http://dx.doi.org/10.1137/04061101X
+func Expm(A *Matrix) *Matrix {
+	if A.height != A.width {
+		panic("matrix.Expm: matrix is not square.")
+	}
+	n := A.height
+	norm := norm1(A)
+
+	// Small norms need no scaling and a low degree approximant.
+	for _, p := range padeThetas[:4] {
+		if norm <= p.theta {
+			return padeExp(A, p.m)
+		}
+	}
+
+	// Scale A so the degree 13 approximant can be used.
+	s := 0
+	if norm > padeThetas[4].theta {
+		s = int(math.Ceil(math.Log2(norm / padeThetas[4].theta)))
+	}
+	As := Zeros(n, n)
+	As.Copy(A)
+	As.Scale(math.Ldexp(1, -s))
+
+	E := padeExp(As, 13)
+	for i := 0; i < s; i++ {
+		E = Mul(E, E)
+	}
+	return E
+}
+
+// padeExp returns the [m/m] Padé approximant to the exponential of A.
+func padeExp(A *Matrix, m int) *Matrix {
+	n := A.height
+	b := padeCoefficients[m]
+	I := Identity(n)
+	A2 := Mul(A, A)
+
+	// exp(A) is approximated by (V - U) \ (V + U), where U contains the odd
+	// powers of A and V the even powers.
+	var U, V *Matrix
+
+	if m == 13 {
+		A4 := Mul(A2, A2)
+		A6 := Mul(A4, A2)
+
+		W := Zeros(n, n)
+		W.axpy(b[13], A6).axpy(b[11], A4).axpy(b[9], A2)
+		W = Mul(A6, W)
+		W.axpy(b[7], A6).axpy(b[5], A4).axpy(b[3], A2).axpy(b[1], I)
+		U = Mul(A, W)
+
+		W.Clear()
+		W.axpy(b[12], A6).axpy(b[10], A4).axpy(b[8], A2)
+		V = Mul(A6, W)
+		V.axpy(b[6], A6).axpy(b[4], A4).axpy(b[2], A2).axpy(b[0], I)
+	} else {
+		// Ak holds A^(j-1) for odd j.
+		W := Zeros(n, n)
+		V = Zeros(n, n)
+		Ak := I
+		for j := 1; j <= m; j += 2 {
+			if j > 1 {
+				Ak = Mul(Ak, A2)
+			}
+			W.axpy(b[j], Ak)
+			V.axpy(b[j - 1], Ak)
+		}
+		U = Mul(A, W)
+	}
+
+	P := Plus(V, U)
+	Q := Minus(V, U)
+	E, ok := solve(Q, P)
+	if !ok {
+		panic("matrix.Expm: singular Padé denominator.")
+	}
+	return E
+}
+
+// axpy calculates A = A + α * B and returns A.
+func (A *Matrix) axpy(α float64, B *Matrix) *Matrix {
+	for i := 0; i < A.height; i++ {
+		backend.Daxpy(A.width, α, B.Row(i), 1, A.Row(i), 1)
+	}
+	return A
+}
+
+// norm1 returns the 1-norm (maximum absolute column sum) of A.
+func norm1(A *Matrix) float64 {
+	sums := make([]float64, A.width)
+	for i := 0; i < A.height; i++ {
+		for j, aij := range A.Row(i) {
+			sums[j] += math.Abs(aij)
+		}
+	}
+	max := 0.0
+	for _, s := range sums {
+		max = math.Max(max, s)
+	}
+	return max
+}
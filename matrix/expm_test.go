@@ -0,0 +1,113 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpmDiagonal(t *testing.T) {
+	A := New(3, 3, []float64{1, 0, 0, 0, -2, 0, 0, 0, 30})
+	E := Expm(A)
+
+	correct := New(3, 3, []float64{math.E, 0, 0, 0, math.Exp(-2), 0, 0, 0, math.Exp(30)})
+	for i := 0; i < 3; i++ {
+		if math.Abs(E.At(i, i) - correct.At(i, i)) > 1e-13 * correct.At(i, i) {
+			t.Fatalf("Wrong result: E(%d, %d) = %v, expected %v", i, i, E.At(i, i), correct.At(i, i))
+		}
+	}
+}
+
+func TestExpmNilpotent(t *testing.T) {
+	A := New(2, 2, []float64{0, 1, 0, 0})
+	E := Expm(A)
+
+	correct := New(2, 2, []float64{1, 1, 0, 1})
+	if !equal(E, correct, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestExpmRotation(t *testing.T) {
+	// Every norm takes a different Padé degree, the last ones need scaling.
+	for _, θ := range []float64{0.001, 0.1, 0.5, 1, 2, 5, 50} {
+		A := New(2, 2, []float64{0, -θ, θ, 0})
+		E := Expm(A)
+
+		c, s := math.Cos(θ), math.Sin(θ)
+		correct := New(2, 2, []float64{c, -s, s, c})
+		if !equal(E, correct, 1e-12, t) {
+			t.Fatalf("Wrong result for θ = %v", θ)
+		}
+	}
+}
+
+func TestExpmInverse(t *testing.T) {
+	n := 100
+	A := randomMatrix(n, n)
+	A.Scale(0.1)
+	B := Zeros(n, n)
+	B.Copy(A)
+	B.Scale(-1)
+	C := Mul(Expm(A), Expm(B))
+
+	if !equal(C, Identity(n), 1e-10, t) {
+		t.FailNow()
+	}
+}
+
+func TestPow(t *testing.T) {
+	n := 20
+	A := randomMatrix(n, n)
+	A.Scale(0.2)
+	C := Identity(n)
+	for k := 0; k <= 9; k++ {
+		if !equal(Pow(A, k), C, 1e-12, t) {
+			t.Fatalf("Wrong result for k = %d", k)
+		}
+		C = MulNaive(C, A)
+	}
+}
+
+func TestPowStrassen(t *testing.T) {
+	n := 200
+	A := randomMatrix(n, n)
+	A.Scale(1 / float64(n))
+	C := MulNaive(MulNaive(MulNaive(A, A), A), MulNaive(A, A))
+	D := Pow(A, 5)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestSqrtm(t *testing.T) {
+	n := 50
+	A := RandomSPD(n, nil)
+	S, err := Sqrtm(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equal(MulNaive(S, S), A, 1e-10, t) {
+		t.FailNow()
+	}
+	if !equal(S, Transpose(S), 1e-10, t) {
+		t.FailNow()
+	}
+}
+
+func TestSqrtmNotSPD(t *testing.T) {
+	A := New(2, 2, []float64{1, 2, 3, 4})
+	if _, err := Sqrtm(A); err != ErrNotSPD {
+		t.Fatalf("Non-symmetric matrix: got error %v, expected %v", err, ErrNotSPD)
+	}
+
+	A = New(2, 2, []float64{1, 2, 2, 1})
+	if _, err := Sqrtm(A); err != ErrNotSPD {
+		t.Fatalf("Indefinite matrix: got error %v, expected %v", err, ErrNotSPD)
+	}
+}
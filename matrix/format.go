@@ -0,0 +1,252 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultExcerpt is the number of rows and columns shown when a matrix is
+// formatted without the Excerpt option. Larger matrices are elided.
+const DefaultExcerpt = 10
+
+// Syntax is the notation used by Formatted.
+type Syntax int
+
+const (
+	// Plain prints the elements in aligned columns.
+	Plain Syntax = iota
+
+	// MATLAB prints a MATLAB (or Octave) matrix literal.
+	MATLAB
+
+	// NumPy prints a numpy.array call, assuming numpy is imported as np.
+	NumPy
+)
+
+// FormatOption changes how Formatted formats a matrix.
+type FormatOption func(*formatter)
+
+// Excerpt shows at most n rows and n columns: the first and last rows and
+// columns, with "⋮" and "…" in place of the others. If n <= 0 the whole matrix
+// is shown.
+func Excerpt(n int) FormatOption {
+	return func(f *formatter) {
+		f.excerpt = n
+	}
+}
+
+// Literal formats the matrix in the given syntax, so it can be pasted into
+// other tools. Literals always show the whole matrix.
+func Literal(s Syntax) FormatOption {
+	return func(f *formatter) {
+		f.syntax = s
+	}
+}
+
+// Formatted returns a value that formats A with the given options, for use
+// with the fmt package:
+//
+//	fmt.Printf("%.3f\n", matrix.Formatted(A, matrix.Excerpt(6)))
+//	fmt.Println(matrix.Formatted(A, matrix.Literal(matrix.NumPy)))
+func Formatted(A *Matrix, options ...FormatOption) fmt.Formatter {
+	f := &formatter{A: A, excerpt: DefaultExcerpt}
+	for _, o := range options {
+		o(f)
+	}
+	return f
+}
+
+// Format implements fmt.Formatter. The verbs v, e, E, f, F, g and G format
+// the elements like the fmt package formats a float64, s is the same as v and
+// q quotes the result of v. The precision, width
+// (the minimum width of a column) and the + flag (always print a sign) apply
+// to every element. The + flag on %v prints the size of the matrix before
+// its elements.
+//
+// Matrices with more than DefaultExcerpt rows or columns are elided, use
+// Formatted for other options.
+func (A *Matrix) Format(s fmt.State, verb rune) {
+	(&formatter{A: A, excerpt: DefaultExcerpt}).Format(s, verb)
+}
+
+type formatter struct {
+	A       *Matrix
+	excerpt int
+	syntax  Syntax
+}
+
+// elided marks a row or column that is left out.
+const elided = -1
+
+func (f *formatter) Format(s fmt.State, verb rune) {
+	A := f.A
+	fverb := byte(verb)
+	prec, ok := s.Precision()
+	switch verb {
+	case 'q':
+		fmt.Fprintf(s, "%q", fmt.Sprint(f))
+		return
+	case 'v', 's':
+		fverb = 'g'
+		if !ok {
+			prec = -1
+		}
+	case 'g', 'G':
+		if !ok {
+			prec = -1
+		}
+	case 'e', 'E', 'f':
+		if !ok {
+			prec = 6
+		}
+	case 'F':
+		fverb = 'f'
+		if !ok {
+			prec = 6
+		}
+	default:
+		fmt.Fprintf(s, "%%!%c(*matrix.Matrix=%d x %d)", verb, A.height, A.width)
+		return
+	}
+	sign := s.Flag('+') && verb != 'v' && verb != 's'
+	width, _ := s.Width()
+
+	if verb == 'v' && s.Flag('+') {
+		switch f.syntax {
+		case MATLAB:
+			fmt.Fprint(s, "% ")
+		case NumPy:
+			fmt.Fprint(s, "# ")
+		}
+		fmt.Fprintf(s, "%d x %d matrix\n", A.height, A.width)
+	}
+
+	if A.height == 0 || A.width == 0 {
+		switch f.syntax {
+		case MATLAB:
+			fmt.Fprintf(s, "zeros(%d, %d)", A.height, A.width)
+		case NumPy:
+			fmt.Fprintf(s, "np.zeros((%d, %d))", A.height, A.width)
+		}
+		return
+	}
+
+	excerpt := f.excerpt
+	if f.syntax != Plain {
+		excerpt = 0
+	}
+	rows := shown(A.height, excerpt)
+	cols := shown(A.width, excerpt)
+
+	// Format all cells and find the width of each column.
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(cols))
+	for r, i := range rows {
+		cells[r] = make([]string, len(cols))
+		for c, j := range cols {
+			var cell string
+			switch {
+			case i == elided && j == elided:
+				cell = "⋱"
+			case i == elided:
+				cell = "⋮"
+			case j == elided:
+				cell = "…"
+			default:
+				cell = f.number(A.At(i, j), fverb, prec, sign)
+			}
+			cells[r][c] = cell
+			if n := utf8.RuneCountInString(cell); n > widths[c] {
+				widths[c] = n
+			}
+		}
+	}
+	for c := range widths {
+		if widths[c] < width {
+			widths[c] = width
+		}
+	}
+
+	var open, sep, rowSep, close, indent string
+	switch f.syntax {
+	case Plain:
+		sep, rowSep = "  ", "\n"
+	case MATLAB:
+		open, sep, rowSep, close, indent = "[", "  ", ";\n", "]", " "
+	case NumPy:
+		open, sep, rowSep, close = "np.array([[", ", ", "],\n", "]])"
+		indent = "          ["
+	}
+
+	b := new(strings.Builder)
+	b.WriteString(open)
+	for r, row := range cells {
+		if r > 0 {
+			b.WriteString(rowSep)
+			b.WriteString(indent)
+		}
+		for c, cell := range row {
+			if c > 0 {
+				b.WriteString(sep)
+			}
+			pad := widths[c] - utf8.RuneCountInString(cell)
+			b.WriteString(strings.Repeat(" ", pad))
+			b.WriteString(cell)
+		}
+	}
+	b.WriteString(close)
+	fmt.Fprint(s, b.String())
+}
+
+// number formats x, spelling infinities and NaN in the syntax of f.
+func (f *formatter) number(x float64, verb byte, prec int, sign bool) string {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		var name string
+		switch {
+		case math.IsNaN(x):
+			name = map[Syntax]string{Plain: "NaN", MATLAB: "NaN", NumPy: "np.nan"}[f.syntax]
+		default:
+			name = map[Syntax]string{Plain: "Inf", MATLAB: "Inf", NumPy: "np.inf"}[f.syntax]
+			if x < 0 {
+				name = "-" + name
+			} else if sign {
+				name = "+" + name
+			}
+		}
+		return name
+	}
+	str := strconv.FormatFloat(x, verb, prec, 64)
+	if sign && x >= 0 && !math.Signbit(x) {
+		str = "+" + str
+	}
+	return str
+}
+
+// shown returns the indices of the rows (or columns) to show out of n, with
+// elided in place of the ones that are left out.
+func shown(n, excerpt int) []int {
+	var idx []int
+	if excerpt <= 0 || n <= excerpt {
+		for i := 0; i < n; i++ {
+			idx = append(idx, i)
+		}
+		return idx
+	}
+	head := (excerpt + 1) / 2
+	tail := excerpt / 2
+	for i := 0; i < head; i++ {
+		idx = append(idx, i)
+	}
+	idx = append(idx, elided)
+	for i := n - tail; i < n; i++ {
+		idx = append(idx, i)
+	}
+	return idx
+}
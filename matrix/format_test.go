@@ -0,0 +1,100 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	A := New(2, 3, []float64{1, -2.5, 3, 40, 5, math.NaN()})
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", " 1  -2.5    3\n40     5  NaN"},
+		{"%.2f", " 1.00  -2.50  3.00\n40.00   5.00   NaN"},
+		{"%+.1f", " +1.0  -2.5  +3.0\n+40.0  +5.0   NaN"},
+		{"%5v", "    1   -2.5      3\n   40      5    NaN"},
+		{"%.1e", "1.0e+00  -2.5e+00  3.0e+00\n4.0e+01   5.0e+00      NaN"},
+		{"%+v", "2 x 3 matrix\n 1  -2.5    3\n40     5  NaN"},
+		{"%s", " 1  -2.5    3\n40     5  NaN"},
+		{"%q", `" 1  -2.5    3\n40     5  NaN"`},
+		{"%d", "%!d(*matrix.Matrix=2 x 3)"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, A); s != test.want {
+			t.Errorf("%s:\n%s\nwant\n%s", test.format, s, test.want)
+		}
+	}
+	if s := A.String(); s != tests[0].want {
+		t.Errorf("String:\n%s\nwant\n%s", s, tests[0].want)
+	}
+	// String shows large matrices in full.
+	if s := Zeros(DefaultExcerpt + 2, 1).String(); strings.Count(s, "0") != DefaultExcerpt + 2 {
+		t.Errorf("String elides:\n%s", s)
+	}
+}
+
+func TestFormatExcerpt(t *testing.T) {
+	A := Zeros(6, 5)
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 5; j++ {
+			A.Set(i, j, float64(10 * i + j))
+		}
+	}
+	want := " 0   1  …   4\n" +
+		"10  11  …  14\n" +
+		" ⋮   ⋮  ⋱   ⋮\n" +
+		"50  51  …  54"
+	if s := fmt.Sprint(Formatted(A, Excerpt(3))); s != want {
+		t.Errorf("Excerpt(3):\n%s\nwant\n%s", s, want)
+	}
+	if s := fmt.Sprint(Formatted(A, Excerpt(0))); s != fmt.Sprint(Formatted(A, Excerpt(6))) {
+		t.Errorf("Excerpt(0) elides:\n%s", s)
+	}
+
+	B := Zeros(20, 1)
+	if s := fmt.Sprint(B); s != "0\n0\n0\n0\n0\n⋮\n0\n0\n0\n0\n0" {
+		t.Errorf("default excerpt:\n%s", s)
+	}
+}
+
+func TestFormatLiteral(t *testing.T) {
+	A := New(2, 2, []float64{1, math.Inf(-1), 30, math.NaN()})
+	tests := []struct {
+		syntax Syntax
+		format string
+		want   string
+	}{
+		{MATLAB, "%v", "[ 1  -Inf;\n 30   NaN]"},
+		{MATLAB, "%+v", "% 2 x 2 matrix\n[ 1  -Inf;\n 30   NaN]"},
+		{NumPy, "%v", "np.array([[ 1, -np.inf],\n          [30,  np.nan]])"},
+		{NumPy, "%+v", "# 2 x 2 matrix\nnp.array([[ 1, -np.inf],\n          [30,  np.nan]])"},
+	}
+	for _, test := range tests {
+		s := fmt.Sprintf(test.format, Formatted(A, Literal(test.syntax)))
+		if s != test.want {
+			t.Errorf("%d %s:\n%s\nwant\n%s", test.syntax, test.format, s, test.want)
+		}
+	}
+
+	// Literals are never elided.
+	B := Zeros(30, 30)
+	s := fmt.Sprint(Formatted(B, Literal(MATLAB), Excerpt(4)))
+	if strings.Count(s, ";") != 29 || strings.Count(s, "0") != 900 {
+		t.Errorf("MATLAB literal of a 30 x 30 matrix is elided:\n%s", s)
+	}
+
+	if s := fmt.Sprint(Formatted(Zeros(0, 3), Literal(MATLAB))); s != "zeros(0, 3)" {
+		t.Errorf("empty MATLAB literal = %q", s)
+	}
+	if s := fmt.Sprint(Formatted(Zeros(2, 0), Literal(NumPy))); s != "np.zeros((2, 0))" {
+		t.Errorf("empty NumPy literal = %q", s)
+	}
+}
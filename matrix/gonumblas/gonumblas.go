@@ -0,0 +1,49 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gonumblas adapts gonum BLAS implementations to matrix.Backend.
+//
+// Any implementation of gonum's blas.Float64 can be used, such as the pure Go
+// gonum.org/v1/gonum/blas/gonum or the cgo OpenBLAS bindings in
+// gonum.org/v1/netlib/blas/netlib:
+//
+//	matrix.SetBackend(gonumblas.Backend{netlib.Implementation{}})
+package gonumblas
+
+import (
+	"github.com/harrydb/go/matrix"
+	"gonum.org/v1/gonum/blas"
+)
+
+// Backend is a matrix.Backend that calls a gonum BLAS implementation.
+//
+// Both use row-major matrices, so only the transpose flags need converting.
+type Backend struct {
+	blas.Float64
+}
+
+var _ matrix.Backend = Backend{}
+
+// Dgemv calculates y = α * A * x + β * y, or y = α * A' * x + β * y if trans
+// is true.
+func (b Backend) Dgemv(trans bool, m, n int, α float64, a []float64, lda int,
+	x []float64, incx int, β float64, y []float64, incy int) {
+
+	b.Float64.Dgemv(transpose(trans), m, n, α, a, lda, x, incx, β, y, incy)
+}
+
+// Dgemm calculates C = α * op(A) * op(B) + β * C.
+func (b Backend) Dgemm(transA, transB bool, m, n, k int, α float64, a []float64, lda int,
+	bb []float64, ldb int, β float64, c []float64, ldc int) {
+
+	b.Float64.Dgemm(transpose(transA), transpose(transB), m, n, k, α, a, lda,
+		bb, ldb, β, c, ldc)
+}
+
+func transpose(trans bool) blas.Transpose {
+	if trans {
+		return blas.Trans
+	}
+	return blas.NoTrans
+}
@@ -0,0 +1,82 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonumblas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/harrydb/go/matrix"
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+const ε = 10e-12
+
+func randomMatrix(m, n int) *matrix.Matrix {
+	A := matrix.Zeros(m, n)
+	for i := 0; i < m; i++ {
+		for j := range A.Row(i) {
+			A.Set(i, j, rand.NormFloat64())
+		}
+	}
+	return A
+}
+
+func equal(A, B *matrix.Matrix, t *testing.T) bool {
+	for i := 0; i < A.Rows(); i++ {
+		for j := 0; j < A.Cols(); j++ {
+			if math.Abs(A.At(i, j) - B.At(i, j)) > ε {
+				t.Errorf("(%d, %d): %v != %v", i, j, A.At(i, j), B.At(i, j))
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TestBackend compares the results with gonum against the native backend.
+func TestBackend(t *testing.T) {
+	A := randomMatrix(37, 23)
+	B := randomMatrix(23, 41)
+	S := randomMatrix(37, 41)
+	x := matrix.Vector(randomMatrix(1, 23).Row(0))
+
+	type result struct {
+		C, D, E, F, G *matrix.Matrix
+		y, z       matrix.Vector
+	}
+	run := func() (r result) {
+		r.C = matrix.MulBLAS(A, B)
+		r.D = matrix.Zeros(37, 41)
+		r.D.Copy(S)
+		r.D.MulSubBLAS(A, B)
+		r.E = matrix.Zeros(23, 41).MulTransABLAS(A, S)
+		r.F = matrix.Zeros(37, 37).MulTransBBLAS(S, S)
+		r.G = matrix.Zeros(37, 23)
+		r.G.Copy(A)
+		r.G.Rank1(0.5, r.F.Row(0), x)
+		r.y = matrix.MulVec(A, x)
+		r.z = matrix.MulVecT(A, r.y)
+		return
+	}
+
+	native := run()
+	matrix.SetBackend(Backend{gonum.Implementation{}})
+	defer matrix.SetBackend(nil)
+	if _, ok := matrix.CurrentBackend().(Backend); !ok {
+		t.Fatal("SetBackend did not change the backend")
+	}
+	g := run()
+
+	for _, p := range [][2]*matrix.Matrix{{native.C, g.C}, {native.D, g.D},
+		{native.E, g.E}, {native.F, g.F}, {native.G, g.G},
+		{matrix.New(37, 1, native.y), matrix.New(37, 1, g.y)},
+		{matrix.New(23, 1, native.z), matrix.New(23, 1, g.z)}} {
+		if !equal(p[0], p[1], t) {
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// The innermost loops of the multiplication routines are provided by three
+// kernels:
+//
+//	axpy(α, x, y)      y = y + α * x
+//	dot(x, y)          returns x · y
+//	gemm4x4(k, a, lda, b, ldb, c, ldc)
+//	                   C = C + A * B for a 4 x k block A and a k x 4 block B,
+//	                   stored row-major with the given strides.
+//
+// There are assembly versions for amd64 (AVX2 and FMA, when the processor
+// supports them) and arm64 (NEON). On other architectures, or when building
+// with the noasm tag, the pure Go versions below are used.
+
+// axpyGo calculates y = y + α * x for the first len(x) elements of y.
+func axpyGo(α float64, x, y []float64) {
+	y = y[:len(x)]
+	for i, xi := range x {
+		y[i] += α * xi
+	}
+}
+
+// dotGo returns the dot product of x and the first len(x) elements of y.
+func dotGo(x, y []float64) float64 {
+	y = y[:len(x)]
+	sum := 0.0
+	for i, xi := range x {
+		sum += xi * y[i]
+	}
+	return sum
+}
+
+// gemm4x4Go calculates C = C + A * B for a 4 x k block A and a k x 4 block B.
+func gemm4x4Go(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	var acc [4][4]float64
+	for p := 0; p < k; p++ {
+		bp := b[p * ldb : p * ldb + 4]
+		for i := range acc {
+			aip := a[i * lda + p]
+			acc[i][0] += aip * bp[0]
+			acc[i][1] += aip * bp[1]
+			acc[i][2] += aip * bp[2]
+			acc[i][3] += aip * bp[3]
+		}
+	}
+	for i := range acc {
+		ci := c[i * ldc : i * ldc + 4]
+		ci[0] += acc[i][0]
+		ci[1] += acc[i][1]
+		ci[2] += acc[i][2]
+		ci[3] += acc[i][3]
+	}
+}
+
+// checkGemm4x4 panics if the blocks passed to gemm4x4 do not fit in their
+// slices, so the assembly versions never read or write out of bounds.
+func checkGemm4x4(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	if k < 0 || lda < k || ldb < 4 || ldc < 4 {
+		panic("matrix.gemm4x4: invalid dimensions.")
+	}
+	if k > 0 {
+		_ = a[3 * lda + k - 1]
+		_ = b[(k - 1) * ldb + 3]
+	}
+	_ = c[3 * ldc + 3]
+}
@@ -0,0 +1,80 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !noasm
+// +build !noasm
+
+package matrix
+
+// useFMA is true when the processor and operating system support AVX2 and FMA.
+var useFMA = hasAVX2FMA()
+
+// kernelName is the name of the kernels in use.
+var kernelName = "go"
+
+func init() {
+	if useFMA {
+		kernelName = "avx2"
+	}
+}
+
+func hasAVX2FMA() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const (
+		fma     = 1 << 12
+		osxsave = 1 << 27
+		avx     = 1 << 28
+	)
+	if ecx1 & (fma | osxsave | avx) != fma | osxsave | avx {
+		return false
+	}
+	// The operating system must save the XMM and YMM registers.
+	if xgetbv() & 6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	const avx2 = 1 << 5
+	return ebx7 & avx2 != 0
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax uint32)
+
+//go:noescape
+func axpyAVX2(α float64, x, y []float64)
+
+//go:noescape
+func dotAVX2(x, y []float64) float64
+
+//go:noescape
+func gemm4x4AVX2(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int)
+
+func axpy(α float64, x, y []float64) {
+	if useFMA {
+		axpyAVX2(α, x, y[:len(x)])
+		return
+	}
+	axpyGo(α, x, y)
+}
+
+func dot(x, y []float64) float64 {
+	if useFMA {
+		return dotAVX2(x, y[:len(x)])
+	}
+	return dotGo(x, y)
+}
+
+func gemm4x4(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	checkGemm4x4(k, a, lda, b, ldb, c, ldc)
+	if useFMA {
+		gemm4x4AVX2(k, a, lda, b, ldb, c, ldc)
+		return
+	}
+	gemm4x4Go(k, a, lda, b, ldb, c, ldc)
+}
@@ -0,0 +1,177 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !noasm
+// +build !noasm
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-4
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	RET
+
+// func axpyAVX2(α float64, x, y []float64)
+//
+// len(y) must equal len(x).
+TEXT ·axpyAVX2(SB), NOSPLIT, $0-56
+	MOVQ x_base+8(FP), SI
+	MOVQ x_len+16(FP), CX
+	MOVQ y_base+32(FP), DI
+	VBROADCASTSD α+0(FP), Y0
+
+	MOVQ CX, BX
+	SHRQ $3, BX
+	JZ   axpy_tail
+
+axpy_loop8:
+	VMOVUPD     (SI), Y1
+	VMOVUPD     32(SI), Y2
+	VFMADD213PD (DI), Y0, Y1
+	VFMADD213PD 32(DI), Y0, Y2
+	VMOVUPD     Y1, (DI)
+	VMOVUPD     Y2, 32(DI)
+	ADDQ        $64, SI
+	ADDQ        $64, DI
+	DECQ        BX
+	JNZ         axpy_loop8
+
+axpy_tail:
+	ANDQ $7, CX
+	JZ   axpy_done
+
+axpy_loop1:
+	VMOVSD      (SI), X1
+	VFMADD213SD (DI), X0, X1
+	VMOVSD      X1, (DI)
+	ADDQ        $8, SI
+	ADDQ        $8, DI
+	DECQ        CX
+	JNZ         axpy_loop1
+
+axpy_done:
+	VZEROUPPER
+	RET
+
+// func dotAVX2(x, y []float64) float64
+//
+// len(y) must equal len(x).
+TEXT ·dotAVX2(SB), NOSPLIT, $0-56
+	MOVQ   x_base+0(FP), SI
+	MOVQ   x_len+8(FP), CX
+	MOVQ   y_base+24(FP), DI
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+
+	MOVQ CX, BX
+	SHRQ $3, BX
+	JZ   dot_tail
+
+dot_loop8:
+	VMOVUPD     (SI), Y2
+	VMOVUPD     32(SI), Y3
+	VFMADD231PD (DI), Y2, Y0
+	VFMADD231PD 32(DI), Y3, Y1
+	ADDQ        $64, SI
+	ADDQ        $64, DI
+	DECQ        BX
+	JNZ         dot_loop8
+
+	// Sum the lanes of both accumulators.
+	VADDPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VHADDPD      X0, X0, X0
+
+dot_tail:
+	ANDQ $7, CX
+	JZ   dot_done
+
+dot_loop1:
+	VMOVSD      (SI), X2
+	VFMADD231SD (DI), X2, X0
+	ADDQ        $8, SI
+	ADDQ        $8, DI
+	DECQ        CX
+	JNZ         dot_loop1
+
+dot_done:
+	VMOVSD X0, ret+48(FP)
+	VZEROUPPER
+	RET
+
+// func gemm4x4AVX2(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int)
+//
+// Each row of the 4 x 4 result is kept in one YMM register. For every p, row
+// p of B is loaded once and multiplied by the broadcast elements A(i, p).
+TEXT ·gemm4x4AVX2(SB), NOSPLIT, $0-104
+	MOVQ k+0(FP), CX
+	MOVQ a_base+8(FP), SI
+	MOVQ lda+32(FP), R8
+	SHLQ $3, R8
+	MOVQ b_base+40(FP), DI
+	MOVQ ldb+64(FP), R9
+	SHLQ $3, R9
+	MOVQ c_base+72(FP), DX
+	MOVQ ldc+96(FP), R10
+	SHLQ $3, R10
+
+	// Pointers to rows 1 to 3 of A.
+	LEAQ (SI)(R8*1), R11
+	LEAQ (R11)(R8*1), R12
+	LEAQ (R12)(R8*1), R13
+
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+
+	TESTQ CX, CX
+	JZ    gemm_store
+
+gemm_loop:
+	VMOVUPD      (DI), Y4
+	VBROADCASTSD (SI), Y5
+	VFMADD231PD  Y4, Y5, Y0
+	VBROADCASTSD (R11), Y6
+	VFMADD231PD  Y4, Y6, Y1
+	VBROADCASTSD (R12), Y7
+	VFMADD231PD  Y4, Y7, Y2
+	VBROADCASTSD (R13), Y8
+	VFMADD231PD  Y4, Y8, Y3
+	ADDQ         $8, SI
+	ADDQ         $8, R11
+	ADDQ         $8, R12
+	ADDQ         $8, R13
+	ADDQ         R9, DI
+	DECQ         CX
+	JNZ          gemm_loop
+
+gemm_store:
+	VADDPD  (DX), Y0, Y0
+	VMOVUPD Y0, (DX)
+	ADDQ    R10, DX
+	VADDPD  (DX), Y1, Y1
+	VMOVUPD Y1, (DX)
+	ADDQ    R10, DX
+	VADDPD  (DX), Y2, Y2
+	VMOVUPD Y2, (DX)
+	ADDQ    R10, DX
+	VADDPD  (DX), Y3, Y3
+	VMOVUPD Y3, (DX)
+	VZEROUPPER
+	RET
@@ -0,0 +1,34 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !noasm
+// +build !noasm
+
+package matrix
+
+// kernelName is the name of the kernels in use. NEON is part of every arm64
+// processor, so there is no need to check for it.
+var kernelName = "neon"
+
+//go:noescape
+func axpyNEON(α float64, x, y []float64)
+
+//go:noescape
+func dotNEON(x, y []float64) float64
+
+//go:noescape
+func gemm4x4NEON(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int)
+
+func axpy(α float64, x, y []float64) {
+	axpyNEON(α, x, y[:len(x)])
+}
+
+func dot(x, y []float64) float64 {
+	return dotNEON(x, y[:len(x)])
+}
+
+func gemm4x4(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	checkGemm4x4(k, a, lda, b, ldb, c, ldc)
+	gemm4x4NEON(k, a, lda, b, ldb, c, ldc)
+}
@@ -0,0 +1,141 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !noasm
+// +build !noasm
+
+#include "textflag.h"
+
+// func axpyNEON(α float64, x, y []float64)
+//
+// len(y) must equal len(x).
+TEXT ·axpyNEON(SB), NOSPLIT, $0-56
+	FMOVD α+0(FP), F0
+	VDUP  V0.D[0], V0.D2
+	MOVD  x_base+8(FP), R0
+	MOVD  x_len+16(FP), R2
+	MOVD  y_base+32(FP), R1
+
+	LSR $2, R2, R3
+	CBZ R3, axpy_tail
+
+axpy_loop4:
+	VLD1.P 32(R0), [V1.D2, V2.D2]
+	VLD1   (R1), [V3.D2, V4.D2]
+	VFMLA  V0.D2, V1.D2, V3.D2
+	VFMLA  V0.D2, V2.D2, V4.D2
+	VST1.P [V3.D2, V4.D2], 32(R1)
+	SUB    $1, R3
+	CBNZ   R3, axpy_loop4
+
+axpy_tail:
+	AND $3, R2
+	CBZ R2, axpy_done
+
+axpy_loop1:
+	FMOVD.P 8(R0), F1
+	FMOVD   (R1), F3
+	FMADDD  F0, F3, F1, F3
+	FMOVD.P F3, 8(R1)
+	SUB     $1, R2
+	CBNZ    R2, axpy_loop1
+
+axpy_done:
+	RET
+
+// func dotNEON(x, y []float64) float64
+//
+// len(y) must equal len(x).
+TEXT ·dotNEON(SB), NOSPLIT, $0-56
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+	MOVD y_base+24(FP), R1
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+
+	LSR $2, R2, R3
+	CBZ R3, dot_reduce
+
+dot_loop4:
+	VLD1.P 32(R0), [V2.D2, V3.D2]
+	VLD1.P 32(R1), [V4.D2, V5.D2]
+	VFMLA  V2.D2, V4.D2, V0.D2
+	VFMLA  V3.D2, V5.D2, V1.D2
+	SUB    $1, R3
+	CBNZ   R3, dot_loop4
+
+dot_reduce:
+	// Sum the lanes of both accumulators into the low lane of V0.
+	VFADD  V1.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+	AND $3, R2
+	CBZ R2, dot_done
+
+dot_loop1:
+	FMOVD.P 8(R0), F2
+	FMOVD.P 8(R1), F4
+	FMADDD  F2, F0, F4, F0
+	SUB     $1, R2
+	CBNZ    R2, dot_loop1
+
+dot_done:
+	FMOVD F0, ret+48(FP)
+	RET
+
+// func gemm4x4NEON(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int)
+//
+// The 4 x 4 block of C is loaded into V16 to V23, two registers per row. For
+// every p, row p of B is loaded once and multiplied by the broadcast elements
+// A(i, p).
+TEXT ·gemm4x4NEON(SB), NOSPLIT, $0-104
+	MOVD k+0(FP), R0
+	MOVD a_base+8(FP), R1
+	MOVD lda+32(FP), R2
+	LSL  $3, R2
+	MOVD b_base+40(FP), R5
+	MOVD ldb+64(FP), R6
+	LSL  $3, R6
+	MOVD c_base+72(FP), R7
+	MOVD ldc+96(FP), R8
+	LSL  $3, R8
+
+	// Pointers to rows 1 to 3 of A and C.
+	ADD R2, R1, R10
+	ADD R2, R10, R11
+	ADD R2, R11, R12
+	ADD R8, R7, R13
+	ADD R8, R13, R14
+	ADD R8, R14, R15
+
+	VLD1 (R7), [V16.D2, V17.D2]
+	VLD1 (R13), [V18.D2, V19.D2]
+	VLD1 (R14), [V20.D2, V21.D2]
+	VLD1 (R15), [V22.D2, V23.D2]
+
+	CBZ R0, gemm_store
+
+gemm_loop:
+	VLD1.P  (R5)(R6), [V0.D2, V1.D2]
+	VLD1R.P 8(R1), [V2.D2]
+	VLD1R.P 8(R10), [V3.D2]
+	VLD1R.P 8(R11), [V4.D2]
+	VLD1R.P 8(R12), [V5.D2]
+	VFMLA   V2.D2, V0.D2, V16.D2
+	VFMLA   V2.D2, V1.D2, V17.D2
+	VFMLA   V3.D2, V0.D2, V18.D2
+	VFMLA   V3.D2, V1.D2, V19.D2
+	VFMLA   V4.D2, V0.D2, V20.D2
+	VFMLA   V4.D2, V1.D2, V21.D2
+	VFMLA   V5.D2, V0.D2, V22.D2
+	VFMLA   V5.D2, V1.D2, V23.D2
+	SUB     $1, R0
+	CBNZ    R0, gemm_loop
+
+gemm_store:
+	VST1 [V16.D2, V17.D2], (R7)
+	VST1 [V18.D2, V19.D2], (R13)
+	VST1 [V20.D2, V21.D2], (R14)
+	VST1 [V22.D2, V23.D2], (R15)
+	RET
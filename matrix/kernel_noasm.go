@@ -0,0 +1,24 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build noasm || !(amd64 || arm64)
+// +build noasm !amd64,!arm64
+
+package matrix
+
+// kernelName is the name of the kernels in use.
+var kernelName = "go"
+
+func axpy(α float64, x, y []float64) {
+	axpyGo(α, x, y)
+}
+
+func dot(x, y []float64) float64 {
+	return dotGo(x, y)
+}
+
+func gemm4x4(k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	checkGemm4x4(k, a, lda, b, ldb, c, ldc)
+	gemm4x4Go(k, a, lda, b, ldb, c, ldc)
+}
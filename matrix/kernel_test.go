@@ -0,0 +1,175 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// The kernels in use are compared with the pure Go versions, which are always
+// compiled. Run the tests with -tags noasm to test the pure Go versions on
+// their own.
+
+func randomSlice(n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	return x
+}
+
+// εmach is the machine epsilon of float64.
+const εmach = 1.0 / (1 << 52)
+
+// kernelSizes are the vector lengths the kernels are tested with: all short
+// ones, for the remainder loops, and some long ones.
+var kernelSizes = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 15, 16, 17, 31, 33, 100, 1000, 10007}
+
+// norm returns the Euclidean norm of x.
+func norm(x []float64) float64 {
+	s := 0.0
+	for _, xi := range x {
+		s += xi * xi
+	}
+	return math.Sqrt(s)
+}
+
+// dotTolerance bounds the difference between two summation orders of the dot
+// product of x and y, n * ε * |x| |y|.
+func dotTolerance(x, y []float64) float64 {
+	return float64(len(x)) * εmach * norm(x) * norm(y[:len(x)])
+}
+
+func TestAxpyKernel(t *testing.T) {
+	t.Log("kernels:", kernelName)
+	for _, n := range kernelSizes {
+		x := randomSlice(n)
+		y := randomSlice(n + 3)
+		z := append([]float64(nil), y...)
+		axpy(0.75, x, y)
+		axpyGo(0.75, x, z)
+		for i := range y {
+			// A fused multiply-add rounds once instead of twice.
+			tol := 2 * εmach * math.Abs(z[i])
+			if i < n {
+				tol += 2 * εmach * math.Abs(0.75 * x[i])
+			}
+			if math.Abs(y[i] - z[i]) > tol {
+				t.Fatalf("n = %d: y[%d] = %v, want %v", n, i, y[i], z[i])
+			}
+		}
+	}
+}
+
+func TestDotKernel(t *testing.T) {
+	for _, n := range kernelSizes {
+		x := randomSlice(n)
+		y := randomSlice(n + 3)
+		d, e := dot(x, y), dotGo(x, y)
+		if math.Abs(d - e) > dotTolerance(x, y) {
+			t.Fatalf("n = %d: dot = %v, want %v", n, d, e)
+		}
+	}
+}
+
+func TestGemm4x4Kernel(t *testing.T) {
+	for _, k := range kernelSizes {
+		lda, ldb, ldc := k + 3, 7, 5
+		a := randomSlice(3 * lda + k)
+		b := randomSlice(k * ldb)
+		c := randomSlice(3 * ldc + 4)
+		d := append([]float64(nil), c...)
+		gemm4x4(k, a, lda, b, ldb, c, ldc)
+		gemm4x4Go(k, a, lda, b, ldb, d, ldc)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				// Element (i, j) is c plus the dot product of row i of a
+				// and column j of b.
+				ai := a[i * lda : i * lda + k]
+				bj := make([]float64, k)
+				for p := range bj {
+					bj[p] = b[p * ldb + j]
+				}
+				e := i * ldc + j
+				tol := float64(k + 1) * εmach * (norm(ai) * norm(bj) + math.Abs(d[e]))
+				if math.Abs(c[e] - d[e]) > tol {
+					t.Fatalf("k = %d: c[%d] = %v, want %v", k, e, c[e], d[e])
+				}
+			}
+		}
+		// The elements between the rows of the block are not touched.
+		for i := range c {
+			if i % ldc >= 4 && c[i] != d[i] {
+				t.Fatalf("k = %d: c[%d] = %v, want %v", k, i, c[i], d[i])
+			}
+		}
+	}
+}
+
+func TestGemm4x4Bounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("gemm4x4 accepted a block that does not fit")
+		}
+	}()
+	gemm4x4(4, make([]float64, 15), 4, make([]float64, 16), 4, make([]float64, 16), 4)
+}
+
+func TestMulSIMD(t *testing.T) {
+	for _, n := range []int{1, 4, 7, 64, 131} {
+		A := randomMatrix(n, n + 2)
+		B := randomMatrix(n + 2, n + 1)
+		C := MulNaive(A, B)
+		D := MulSIMD(A, B)
+
+		if !equal(C, D, ε, t) {
+			t.Fatalf("n = %d", n)
+		}
+	}
+}
+
+func TestMulAddSIMDSubMatrix(t *testing.T) {
+	n := 70
+	A := randomMatrix(n, n).SubMatrix(3, 5, 50, 41)
+	B := randomMatrix(n, n).SubMatrix(1, 2, 41, 37)
+	C := randomMatrix(n, n)
+	D := Zeros(n, n)
+	D.Copy(C)
+
+	C.SubMatrix(9, 6, 50, 37).MulAddBLAS(A, B)
+	D.SubMatrix(9, 6, 50, 37).MulAddSIMD(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkMulSIMD_____512(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 512
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		MulSIMD(A, B)
+	}
+}
+
+func BenchmarkMulSIMD_____128(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 128
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		MulSIMD(A, B)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import "math"
+
+// solve returns the solution X of A * X = B for a square matrix A.
+//
+// It uses Gaussian elimination with partial pivoting. A and B are not
+// modified. The second return value is false if A is singular.
+func solve(A, B *Matrix) (*Matrix, bool) {
+	n := A.height
+	LU := Zeros(n, n)
+	LU.Copy(A)
+	X := Zeros(B.height, B.width)
+	X.Copy(B)
+
+	for k := 0; k < n; k++ {
+		// Find the pivot.
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(LU.At(i, k)) > math.Abs(LU.At(p, k)) {
+				p = i
+			}
+		}
+		if LU.At(p, k) == 0 {
+			return nil, false
+		}
+		if p != k {
+			backend.Dswap(n, LU.Row(p), 1, LU.Row(k), 1)
+			backend.Dswap(X.width, X.Row(p), 1, X.Row(k), 1)
+		}
+
+		// Eliminate column k below the diagonal.
+		LUk := LU.Row(k)
+		Xk := X.Row(k)
+		for i := k + 1; i < n; i++ {
+			LUi := LU.Row(i)
+			lik := LUi[k] / LUk[k]
+			backend.Daxpy(n - k, -lik, LUk[k:], 1, LUi[k:], 1)
+			backend.Daxpy(X.width, -lik, Xk, 1, X.Row(i), 1)
+		}
+	}
+
+	// Back substitution.
+	for i := n - 1; i >= 0; i-- {
+		LUi := LU.Row(i)
+		Xi := X.Row(i)
+		for j := i + 1; j < n; j++ {
+			backend.Daxpy(X.width, -LUi[j], X.Row(j), 1, Xi, 1)
+		}
+		backend.Dscal(X.width, 1 / LUi[i], Xi, 1)
+	}
+
+	return X, true
+}
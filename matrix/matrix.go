@@ -5,7 +5,6 @@
 package matrix
 
 import (
-	"bytes"
 	"fmt"
 )
 
@@ -15,15 +14,8 @@ type Matrix struct {
 	data          []float64
 }
 
+// String returns all elements of a in aligned columns. Unlike Format it never
+// elides rows or columns.
 func (a *Matrix) String() string {
-	buffer := bytes.NewBufferString("")
-
-	for i := 0; i < a.height; i++ {
-		for j := 0; j < a.width; j++ {
-			fmt.Fprint(buffer, a.At(i, j), " ")
-		}
-		fmt.Fprintln(buffer)
-	}
-
-	return string(buffer.Bytes())
+	return fmt.Sprint(Formatted(a, Excerpt(0)))
 }
@@ -0,0 +1,166 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package matrixtest provides helpers for testing code that uses package
+// matrix: approximate comparison of matrices, reports of the entries that
+// differ most, and testing/quick generators.
+package matrixtest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/harrydb/go/matrix"
+)
+
+// Tolerance says when two numbers are approximately equal.
+//
+// Two numbers a and b are equal if any of the following holds:
+//
+//	|a - b| <= Abs
+//	|a - b| <= Rel * max(|a|, |b|)
+//	a and b are at most ULP representable numbers apart
+//
+// The zero Tolerance only accepts numbers that are exactly equal. NaN is
+// never equal to anything, and an infinity only to itself.
+type Tolerance struct {
+	Abs, Rel float64
+	ULP      uint64
+}
+
+// Equal reports whether a and b are equal within the tolerance.
+func (tol Tolerance) Equal(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		// The difference would be infinite, and so would the relative
+		// tolerance.
+		return false
+	}
+	d := math.Abs(a - b)
+	return d <= tol.Abs ||
+		d <= tol.Rel * math.Max(math.Abs(a), math.Abs(b)) ||
+		ULPs(a, b) <= tol.ULP
+}
+
+// ULPs returns the number of representable float64 values between a and b,
+// counting one of them. Zero and negative zero are 0 apart.
+func ULPs(a, b float64) uint64 {
+	ia, ib := ordered(a), ordered(b)
+	if ia < ib {
+		ia, ib = ib, ia
+	}
+	return uint64(ia) - uint64(ib)
+}
+
+// ordered maps the bits of x to an integer that has the same order as x.
+func ordered(x float64) int64 {
+	i := int64(math.Float64bits(x))
+	if i < 0 {
+		i = math.MinInt64 - i
+	}
+	return i
+}
+
+// EqualApprox reports whether A and B have the same size and all elements are
+// equal within the tolerance.
+func EqualApprox(A, B *matrix.Matrix, tol Tolerance) bool {
+	if A.Rows() != B.Rows() || A.Cols() != B.Cols() {
+		return false
+	}
+	for i := 0; i < A.Rows(); i++ {
+		Bi := B.Row(i)
+		for j, aij := range A.Row(i) {
+			if !tol.Equal(aij, Bi[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Entry is an element that differs between two matrices.
+type Entry struct {
+	Row, Col int
+	A, B     float64
+}
+
+// Diff returns the elements of A and B that are not equal within the
+// tolerance, the largest absolute differences first. At most worst entries are
+// returned, all of them if worst <= 0. A and B must have the same size.
+func Diff(A, B *matrix.Matrix, tol Tolerance, worst int) []Entry {
+	if A.Rows() != B.Rows() || A.Cols() != B.Cols() {
+		panic("matrixtest.Diff: matrices have different sizes.")
+	}
+	var entries []Entry
+	for i := 0; i < A.Rows(); i++ {
+		Bi := B.Row(i)
+		for j, aij := range A.Row(i) {
+			if !tol.Equal(aij, Bi[j]) {
+				entries = append(entries, Entry{i, j, aij, Bi[j]})
+			}
+		}
+	}
+	sort.SliceStable(entries, func(k, l int) bool {
+		return entries[k].absDiff() > entries[l].absDiff()
+	})
+	if worst > 0 && len(entries) > worst {
+		entries = entries[:worst]
+	}
+	return entries
+}
+
+// absDiff returns |A - B|, with NaNs sorted first.
+func (e Entry) absDiff() float64 {
+	d := math.Abs(e.A - e.B)
+	if math.IsNaN(d) {
+		return math.Inf(1)
+	}
+	return d
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("(%d, %d): %v != %v (abs %.3g, rel %.3g, %d ulp)",
+		e.Row, e.Col, e.A, e.B, math.Abs(e.A - e.B),
+		math.Abs(e.A - e.B) / math.Max(math.Abs(e.A), math.Abs(e.B)), ULPs(e.A, e.B))
+}
+
+// Report describes how A and B differ, showing at most worst entries. It
+// returns the empty string if A and B are equal within the tolerance.
+func Report(A, B *matrix.Matrix, tol Tolerance, worst int) string {
+	if A.Rows() != B.Rows() || A.Cols() != B.Cols() {
+		return fmt.Sprintf("sizes differ: %d x %d != %d x %d",
+			A.Rows(), A.Cols(), B.Rows(), B.Cols())
+	}
+	all := Diff(A, B, tol, 0)
+	if len(all) == 0 {
+		return ""
+	}
+	b := new(bytes.Buffer)
+	fmt.Fprintf(b, "%d of %d elements differ", len(all), A.Rows() * A.Cols())
+	if worst > 0 && len(all) > worst {
+		all = all[:worst]
+		fmt.Fprintf(b, ", the %d worst", worst)
+	}
+	fmt.Fprintln(b, ":")
+	for _, e := range all {
+		fmt.Fprintln(b, "\t", e)
+	}
+	return b.String()
+}
+
+// AssertEqual reports an error on t, showing the five worst elements, if A
+// and B are not equal within the tolerance. It returns whether they are.
+func AssertEqual(t testing.TB, A, B *matrix.Matrix, tol Tolerance) bool {
+	t.Helper()
+	if r := Report(A, B, tol, 5); r != "" {
+		t.Error(r)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrixtest
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/harrydb/go/matrix"
+)
+
+func TestULPs(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		ulp  uint64
+	}{
+		{1, 1, 0},
+		{1, math.Nextafter(1, 2), 1},
+		{math.Nextafter(1, 0), math.Nextafter(1, 2), 2},
+		{0, math.Copysign(0, -1), 0},
+		{-math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, 2},
+		{-1, -math.Nextafter(1, 2), 1},
+	}
+	for _, test := range tests {
+		if u := ULPs(test.a, test.b); u != test.ulp {
+			t.Errorf("ULPs(%v, %v) = %d, want %d", test.a, test.b, u, test.ulp)
+		}
+		if u := ULPs(test.b, test.a); u != test.ulp {
+			t.Errorf("ULPs(%v, %v) = %d, want %d", test.b, test.a, u, test.ulp)
+		}
+	}
+}
+
+func TestToleranceEqual(t *testing.T) {
+	tests := []struct {
+		tol   Tolerance
+		a, b  float64
+		equal bool
+	}{
+		{Tolerance{}, 1, 1, true},
+		{Tolerance{}, 1, math.Nextafter(1, 2), false},
+		{Tolerance{ULP: 1}, 1, math.Nextafter(1, 2), true},
+		{Tolerance{Abs: 0.1}, 1, 1.05, true},
+		{Tolerance{Abs: 0.1}, 1, 1.2, false},
+		{Tolerance{Rel: 0.1}, 100, 105, true},
+		{Tolerance{Rel: 0.1}, 0.01, 0.02, false},
+		{Tolerance{Abs: 1, Rel: 1, ULP: 1 << 62}, math.NaN(), math.NaN(), false},
+		{Tolerance{}, math.Inf(1), math.Inf(1), true},
+		{Tolerance{Rel: 0.1}, math.Inf(-1), math.Inf(-1), true},
+		{Tolerance{Abs: 1, Rel: 1, ULP: 1 << 62}, math.Inf(1), math.Inf(-1), false},
+		{Tolerance{Abs: 1, Rel: 1, ULP: 1 << 62}, math.Inf(-1), math.Inf(1), false},
+		{Tolerance{Abs: 1, Rel: 1, ULP: 1 << 62}, math.Inf(1), 1, false},
+		{Tolerance{Abs: 1, Rel: 1, ULP: 1 << 62}, -1, math.Inf(-1), false},
+		{Tolerance{ULP: 1}, math.MaxFloat64, math.Inf(1), false},
+	}
+	for _, test := range tests {
+		if e := test.tol.Equal(test.a, test.b); e != test.equal {
+			t.Errorf("%+v.Equal(%v, %v) = %v", test.tol, test.a, test.b, e)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	A := matrix.New(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	B := matrix.New(2, 3, []float64{1, 2.5, 3, 4, 5, 9})
+	tol := Tolerance{Abs: 0.1}
+
+	if EqualApprox(A, B, tol) {
+		t.Error("EqualApprox is true for different matrices")
+	}
+	if !EqualApprox(A, A, tol) {
+		t.Error("EqualApprox is false for equal matrices")
+	}
+	if EqualApprox(A, matrix.Zeros(3, 2), tol) {
+		t.Error("EqualApprox is true for matrices of different sizes")
+	}
+
+	d := Diff(A, B, tol, 0)
+	if len(d) != 2 || d[0] != (Entry{1, 2, 6, 9}) || d[1] != (Entry{0, 1, 2, 2.5}) {
+		t.Errorf("Diff = %v", d)
+	}
+	if d := Diff(A, B, tol, 1); len(d) != 1 || d[0].Row != 1 {
+		t.Errorf("Diff with worst = 1: %v", d)
+	}
+
+	r := Report(A, B, tol, 1)
+	if !strings.HasPrefix(r, "2 of 6 elements differ, the 1 worst:") || !strings.Contains(r, "(1, 2)") {
+		t.Errorf("Report = %q", r)
+	}
+	if r := Report(A, A, tol, 1); r != "" {
+		t.Errorf("Report of equal matrices = %q", r)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	f := func(M Matrix, T Triple, S SquareTriple) bool {
+		n := S.A.Rows()
+		return M.Rows() >= 1 && M.Cols() >= 1 &&
+			T.A.Cols() == T.B.Rows() && T.B.Cols() == T.C.Rows() &&
+			n % 2 == 0 && S.A.Cols() == n && S.B.Rows() == n && S.C.Cols() == n
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 20}); err != nil {
+		t.Error(err)
+	}
+}
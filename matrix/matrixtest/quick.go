@@ -0,0 +1,67 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrixtest
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/harrydb/go/matrix"
+)
+
+// The types below implement testing/quick.Generator, so they can be used as
+// arguments of functions tested with quick.Check. All elements are drawn from
+// the standard normal distribution.
+
+// Matrix is a matrix with between 1 and size rows and columns.
+type Matrix struct {
+	*matrix.Matrix
+}
+
+// Generate implements quick.Generator.
+func (Matrix) Generate(rnd *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Matrix{random(rnd, dim(rnd, size), dim(rnd, size))})
+}
+
+// Triple holds matrices A, B and C of sizes m x k, k x n and n x p, so the
+// products A * B and B * C exist. Each dimension is between 1 and size.
+type Triple struct {
+	A, B, C *matrix.Matrix
+}
+
+// Generate implements quick.Generator.
+func (Triple) Generate(rnd *rand.Rand, size int) reflect.Value {
+	m, k, n, p := dim(rnd, size), dim(rnd, size), dim(rnd, size), dim(rnd, size)
+	return reflect.ValueOf(Triple{random(rnd, m, k), random(rnd, k, n), random(rnd, n, p)})
+}
+
+// SquareTriple holds three n x n matrices A, B and C, where n is even and
+// between size and 2 * size. The Strassen variants only recurse on even
+// sizes of 80 and up, which this makes likely for the default size of
+// testing/quick.
+type SquareTriple struct {
+	A, B, C *matrix.Matrix
+}
+
+// Generate implements quick.Generator.
+func (SquareTriple) Generate(rnd *rand.Rand, size int) reflect.Value {
+	n := 2 * (size / 2 + rnd.Intn(size / 2 + 1))
+	if n == 0 {
+		n = 2
+	}
+	return reflect.ValueOf(SquareTriple{random(rnd, n, n), random(rnd, n, n), random(rnd, n, n)})
+}
+
+// dim returns a dimension between 1 and size.
+func dim(rnd *rand.Rand, size int) int {
+	if size < 1 {
+		return 1
+	}
+	return 1 + rnd.Intn(size)
+}
+
+func random(rnd *rand.Rand, m, n int) *matrix.Matrix {
+	return matrix.RandomNormal(m, n, rnd)
+}
@@ -0,0 +1,13 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import "syscall"
+
+// madvise tells the operating system that the pages of b are not needed.
+// Because the mapping is shared, changes are kept in the file.
+func madvise(b []byte) {
+	syscall.Madvise(b, syscall.MADV_DONTNEED)
+}
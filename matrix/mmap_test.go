@@ -0,0 +1,89 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+
+package matrix
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMapped(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "a.mat")
+	A := randomMatrix(30, 20)
+
+	M, err := CreateMapped(name, 30, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	M.Copy(A)
+	if err := M.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if err := M.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenMapped(name, 20, 20, false); err == nil {
+		t.Error("OpenMapped accepted the wrong dimensions")
+	}
+	M, err = OpenMapped(name, 30, 20, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer M.Close()
+	if !equal(A, M.Matrix, 0, t) {
+		t.FailNow()
+	}
+	if !equal(A.SubMatrix(3, 4, 10, 7), M.SubMatrix(3, 4, 10, 7), 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulOutOfCoreMapped(t *testing.T) {
+	dir := t.TempDir()
+	m, k, n := 150, 130, 170
+	A := randomMatrix(m, k)
+	B := randomMatrix(k, n)
+
+	MA, err := CreateMapped(filepath.Join(dir, "a.mat"), m, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MA.Close()
+	MB, err := CreateMapped(filepath.Join(dir, "b.mat"), k, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MB.Close()
+	MC, err := CreateMapped(filepath.Join(dir, "c.mat"), m, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MC.Close()
+	MA.Copy(A)
+	MB.Copy(B)
+
+	MulOutOfCore(MC.Matrix, MA.Matrix, MB.Matrix, 64)
+
+	// Released pages are read back from the file.
+	if !equal(A, MA.Matrix, 0, t) {
+		t.FailNow()
+	}
+	if !equal(MulNaive(A, B), MC.Matrix, ε, t) {
+		t.FailNow()
+	}
+
+	// The Mul variants work on mapped matrices too.
+	A2 := A.SubMatrix(0, 0, 128, 128)
+	B2 := B.SubMatrix(0, 0, 128, 128)
+	MA2 := MA.SubMatrix(0, 0, 128, 128)
+	MB2 := MB.SubMatrix(0, 0, 128, 128)
+	if !equal(MulNaive(A2, B2), MulDouglas(MA2, MB2), ε, t) {
+		t.FailNow()
+	}
+}
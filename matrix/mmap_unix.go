@@ -0,0 +1,157 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+
+package matrix
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Mapped is a matrix whose data is a memory-mapped file.
+//
+// The file holds the elements row by row as float64 values in the byte order
+// of the machine, without a header, which is the layout of a Matrix. The
+// embedded Matrix can be used like any other: Row, At, SubMatrix and the Mul
+// variants work unchanged, with the operating system reading pages from the
+// file as they are needed. Use MulOutOfCore to multiply matrices that do not
+// fit in memory.
+//
+// The Matrix must not be used after Close.
+type Mapped struct {
+	*Matrix
+	file *os.File
+	mem  []byte
+}
+
+// mappings holds the open mappings, so release can find the mapping that a
+// (sub)matrix belongs to.
+var mappings struct {
+	sync.Mutex
+	list []*Mapped
+}
+
+// CreateMapped creates (or truncates) the named file with room for an m x n
+// matrix and maps it into memory. The matrix is zero-filled.
+func CreateMapped(name string, m, n int) (*Mapped, error) {
+	if m <= 0 || n <= 0 {
+		panic("matrix.CreateMapped: invalid dimensions.")
+	}
+	f, err := os.OpenFile(name, os.O_RDWR | os.O_CREATE | os.O_TRUNC, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(int64(m) * int64(n) * 8); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return mapFile(f, m, n, true)
+}
+
+// OpenMapped maps the named file, which must hold an m x n matrix, into
+// memory. If writable is false the matrix must not be changed: writing to it
+// crashes the program.
+func OpenMapped(name string, m, n int, writable bool) (*Mapped, error) {
+	if m <= 0 || n <= 0 {
+		panic("matrix.OpenMapped: invalid dimensions.")
+	}
+	flag := os.O_RDONLY
+	if writable {
+		flag = os.O_RDWR
+	}
+	f, err := os.OpenFile(name, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() != int64(m) * int64(n) * 8 {
+		f.Close()
+		return nil, errors.New("matrix: size of " + name + " does not match the dimensions")
+	}
+	return mapFile(f, m, n, writable)
+}
+
+func mapFile(f *os.File, m, n int, writable bool) (*Mapped, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	mem, err := syscall.Mmap(int(f.Fd()), 0, m * n * 8, prot, syscall.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, &os.PathError{Op: "mmap", Path: f.Name(), Err: err}
+	}
+	data := unsafe.Slice((*float64)(unsafe.Pointer(&mem[0])), m * n)
+	M := &Mapped{&Matrix{m, n, n, data}, f, mem}
+
+	mappings.Lock()
+	mappings.list = append(mappings.list, M)
+	mappings.Unlock()
+	return M, nil
+}
+
+// Sync writes changes to the matrix to the file.
+func (M *Mapped) Sync() error {
+	return M.file.Sync()
+}
+
+// Close unmaps the matrix and closes the file. Changes are written to the file
+// by the operating system, call Sync first to wait for that.
+func (M *Mapped) Close() error {
+	mappings.Lock()
+	for i, N := range mappings.list {
+		if N == M {
+			mappings.list = append(mappings.list[:i], mappings.list[i + 1:]...)
+			break
+		}
+	}
+	mappings.Unlock()
+
+	M.Matrix.data = nil
+	err := syscall.Munmap(M.mem)
+	M.mem = nil
+	if e := M.file.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// release gives the pages that hold the elements of A back to the operating
+// system, if A is (part of) a Mapped matrix. The data stays in the file and
+// is read again when it is used.
+func release(A *Matrix) {
+	if len(A.data) == 0 {
+		return
+	}
+	lo := uintptr(unsafe.Pointer(&A.data[0]))
+	hi := lo + uintptr(len(A.data)) * 8
+
+	mappings.Lock()
+	defer mappings.Unlock()
+	for _, M := range mappings.list {
+		base := uintptr(unsafe.Pointer(&M.mem[0]))
+		if lo < base || hi > base + uintptr(len(M.mem)) {
+			continue
+		}
+		// Mappings start at a page boundary, round to whole pages.
+		page := uintptr(os.Getpagesize())
+		start := (lo - base) &^ (page - 1)
+		end := (hi - base + page - 1) &^ (page - 1)
+		if end > uintptr(len(M.mem)) {
+			end = uintptr(len(M.mem))
+		}
+		madvise(M.mem[start:end])
+		return
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || illumos || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd illumos netbsd openbsd solaris
+
+package matrix
+
+// madvise does nothing: the syscall package only provides madvise on Linux.
+// The operating system still evicts pages when memory runs low.
+func madvise(b []byte) {}
@@ -0,0 +1,64 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import "math"
+
+// MulAccurate returns A * B.
+//
+// Every element is calculated as if with twice the working precision, using
+// error-free transformations of the products and sums. The result is
+// accurate even when the dot products suffer from cancellation, which makes it
+// suitable as a reference for the error of the faster algorithms. It is also
+// several times slower than MulNaive.
+//
+//	Original paper:
+//	T. Ogita, S. M. Rump and S. Oishi, 2005.
+//	Accurate Sum and Dot Product.
+//	http://dx.doi.org/10.1137/030601818
+func MulAccurate(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.width).MulAccurate(A, B)
+}
+
+// MulAccurate calculates C = A * B and returns C.
+func (C *Matrix) MulAccurate(A, B *Matrix) *Matrix {
+	// Running sums and their accumulated errors for one row of C.
+	errors := make([]float64, C.width)
+
+	for i := 0; i < A.height; i++ {
+		Ci := C.Row(i)
+		for k := range Ci {
+			Ci[k] = 0
+			errors[k] = 0
+		}
+		for j, aij := range A.Row(i) {
+			for k, bjk := range B.Row(j) {
+				p, pe := twoProduct(aij, bjk)
+				s, se := twoSum(Ci[k], p)
+				Ci[k] = s
+				errors[k] += pe + se
+			}
+		}
+		for k := range Ci {
+			Ci[k] += errors[k]
+		}
+	}
+	return C
+}
+
+// twoSum returns s = fl(a + b) and the rounding error e, with a + b = s + e.
+func twoSum(a, b float64) (s, e float64) {
+	s = a + b
+	z := s - a
+	e = (a - (s - z)) + (b - z)
+	return s, e
+}
+
+// twoProduct returns p = fl(a * b) and the rounding error e, with a * b = p + e.
+func twoProduct(a, b float64) (p, e float64) {
+	p = a * b
+	e = math.FMA(a, b, -p)
+	return p, e
+}
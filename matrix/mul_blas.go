@@ -4,51 +4,37 @@
 
 package matrix
 
-import "github.com/ziutek/blas"
-
 // MulBLAS returns A * B.
 //
-// Performance of this implementation is improved by using level 1 BLAS functions.
+// The product is calculated by the Dgemm routine of the current Backend.
 func MulBLAS(A, B *Matrix) *Matrix {
 	return Zeros(A.height, B.width).MulAddBLAS(A, B)
 }
 
 // MulBLAS calculates C = A * B.
 func (C *Matrix) MulBLAS(A, B *Matrix) *Matrix {
-	for i := 0; i < A.height; i++ {
-		Ci := C.Row(i)
-		for k := range Ci {
-			Ci[k] = 0
-		}
-		for j, aij := range A.Row(i) {
-			// Ci += aij * Bj
-			blas.Daxpy(C.width, aij, B.Row(j), 1, Ci, 1)
-		}
-	}
-	return C
+	return C.gemm(false, false, 1, A, B, 0)
 }
 
 // MulAddBLAS calculates C = C + A * B.
 func (C *Matrix) MulAddBLAS(A, B *Matrix) *Matrix {
-	for i := 0; i < A.height; i++ {
-		Ci := C.Row(i)
-		for j, aij := range A.Row(i) {
-			// Ci = Ci + aij * Bj
-			blas.Daxpy(C.width, aij, B.Row(j), 1, Ci, 1)
-		}
-	}
-	return C
+	return C.gemm(false, false, 1, A, B, 1)
 }
 
 // MulSubBLAS calculates C = C - A * B.
 func (C *Matrix) MulSubBLAS(A, B *Matrix) *Matrix {
-	for i := 0; i < A.height; i++ {
-		Ci := C.Row(i)
-		for j, aij := range A.Row(i) {
-			// Ci = Ci - aij * Bj
-			blas.Daxpy(C.width, -aij, B.Row(j), 1, Ci, 1)
-		}
+	return C.gemm(false, false, -1, A, B, 1)
+}
+
+// gemm calculates C = α * op(A) * op(B) + β * C with the current Backend and
+// returns C, where op(X) is transpose(X) if the corresponding trans flag is
+// true and X otherwise.
+func (C *Matrix) gemm(transA, transB bool, α float64, A, B *Matrix, β float64) *Matrix {
+	k := A.width
+	if transA {
+		k = A.height
 	}
+	backend.Dgemm(transA, transB, C.height, C.width, k, α, A.data, A.stride,
+		B.data, B.stride, β, C.data, C.stride)
 	return C
 }
-
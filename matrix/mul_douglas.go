@@ -19,10 +19,29 @@ func MulDouglas(A, B *Matrix) *Matrix {
 	return Zeros(A.height, B.width).MulDouglas(A, B)
 }
 
+// MulDouglas calculates C = A * B and returns C.
 func (C *Matrix) MulDouglas(A, B *Matrix) *Matrix {
+	return C.MulDouglasWorkspace(A, B, nil)
+}
+
+// MulDouglasWorkspace calculates C = A * B and returns C.
+//
+// Scratch space is taken from Workspace w, which may be nil.
+func (C *Matrix) MulDouglasWorkspace(A, B *Matrix, w *Workspace) *Matrix {
+	C.mulDouglas(A, B, w, -1)
+	return C
+}
+
+// mulDouglas calculates C = A * B with at most depth levels of recursion
+// (unlimited if depth < 0).
+//
+// Unlike the exported methods it does not return C, this keeps the quadrant
+// headers on the stack so a recursion with a Workspace does not allocate.
+func (C *Matrix) mulDouglas(A, B *Matrix, w *Workspace, depth int) {
 
-	if A.width < 80 || A.height % 2 != 0 || A.width % 2 != 0 || B.width % 2 != 0 {
-		return C.MulBLAS(A, B)
+	if depth == 0 || A.width < 80 || A.height % 2 != 0 || A.width % 2 != 0 || B.width % 2 != 0 {
+		C.MulBLAS(A, B)
+		return
 	}
 
 	m := A.height / 2
@@ -42,31 +61,32 @@ func (C *Matrix) MulDouglas(A, B *Matrix) *Matrix {
 	C22 := C.SubMatrix(n, n, n, n)
 
 	// Allocate scratch space
-	X := Zeros(n, n)
-	Y := Zeros(n, n)
+	mark := w.mark()
+	X := w.get(n, n)
+	Y := w.get(n, n)
 
 	// Perform calculations.
 	X.Minus(A11, A21)
 	Y.Minus(B22, B12)
-	C21.MulDouglas(X, Y)
+	C21.mulDouglas(X, Y, w, depth - 1)
 	X.PlusBLAS(A21, A22)
 	Y.Minus(B12, B11)
-	C22.MulDouglas(X, Y)
+	C22.mulDouglas(X, Y, w, depth - 1)
 	X.SubBLAS(A11)
 	Y.Minus(B22, Y)
-	C12.MulDouglas(X, Y)
+	C12.mulDouglas(X, Y, w, depth - 1)
 	X.Minus(A12, X)
-	C11.MulDouglas(X, B22)
-	X.MulDouglas(A11, B11)
+	C11.mulDouglas(X, B22, w, depth - 1)
+	X.mulDouglas(A11, B11, w, depth - 1)
 	C12.AddBLAS(X)
 	C21.AddBLAS(C12)
 	C12.AddBLAS(C22)
 	C22.AddBLAS(C21) // Final c22.
 	C12.AddBLAS(C11) // Final c12.
 	Y.SubBLAS(B21)
-	C11.MulDouglas(A22, Y)
+	C11.mulDouglas(A22, Y, w, depth - 1)
 	C21.SubBLAS(C11) // Final c21.
-	C11.MulDouglas(A12, B21)
+	C11.mulDouglas(A12, B21, w, depth - 1)
 	C11.AddBLAS(X) // Final c11.
-	return C
+	w.release(mark)
 }
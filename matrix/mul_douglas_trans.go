@@ -0,0 +1,124 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulDouglasTransA returns transpose(A) * B.
+//
+// This is MulDouglas with A read in transposed order. The quadrants of
+// transpose(A) are the transposed quadrants of A with A12 and A21 swapped, and
+// sums of transposed quadrants are transposed sums, so the scratch space for A
+// simply holds the untransposed sums.
+func MulDouglasTransA(A, B *Matrix) *Matrix {
+	return Zeros(A.width, B.width).MulDouglasTransA(A, B)
+}
+
+// MulDouglasTransA calculates C = transpose(A) * B and returns C.
+func (C *Matrix) MulDouglasTransA(A, B *Matrix) *Matrix {
+
+	if A.width < 80 || A.height != A.width || B.height != B.width || A.height != B.height || A.height % 2 != 0 {
+		return C.MulTransABLAS(A, B)
+	}
+
+	n := A.height / 2
+	A11 := A.SubMatrix(0, 0, n, n)
+	A12 := A.SubMatrix(n, 0, n, n) // transpose(A)12 = transpose(A21)
+	A21 := A.SubMatrix(0, n, n, n) // transpose(A)21 = transpose(A12)
+	A22 := A.SubMatrix(n, n, n, n)
+	B11 := B.SubMatrix(0, 0, n, n)
+	B12 := B.SubMatrix(0, n, n, n)
+	B21 := B.SubMatrix(n, 0, n, n)
+	B22 := B.SubMatrix(n, n, n, n)
+	C11 := C.SubMatrix(0, 0, n, n)
+	C12 := C.SubMatrix(0, n, n, n)
+	C21 := C.SubMatrix(n, 0, n, n)
+	C22 := C.SubMatrix(n, n, n, n)
+
+	// Allocate scratch space
+	X := Zeros(n, n)
+	Y := Zeros(n, n)
+
+	// Perform calculations.
+	X.Minus(A11, A21)
+	Y.Minus(B22, B12)
+	C21.MulDouglasTransA(X, Y)
+	X.PlusBLAS(A21, A22)
+	Y.Minus(B12, B11)
+	C22.MulDouglasTransA(X, Y)
+	X.SubBLAS(A11)
+	Y.Minus(B22, Y)
+	C12.MulDouglasTransA(X, Y)
+	X.Minus(A12, X)
+	C11.MulDouglasTransA(X, B22)
+	X.MulDouglasTransA(A11, B11)
+	C12.AddBLAS(X)
+	C21.AddBLAS(C12)
+	C12.AddBLAS(C22)
+	C22.AddBLAS(C21) // Final c22.
+	C12.AddBLAS(C11) // Final c12.
+	Y.SubBLAS(B21)
+	C11.MulDouglasTransA(A22, Y)
+	C21.SubBLAS(C11) // Final c21.
+	C11.MulDouglasTransA(A12, B21)
+	C11.AddBLAS(X) // Final c11.
+	return C
+}
+
+// MulDouglasTransB returns A * transpose(B).
+//
+// This is MulDouglas with B read in transposed order, see MulDouglasTransA.
+func MulDouglasTransB(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.height).MulDouglasTransB(A, B)
+}
+
+// MulDouglasTransB calculates C = A * transpose(B) and returns C.
+func (C *Matrix) MulDouglasTransB(A, B *Matrix) *Matrix {
+
+	if A.width < 80 || A.height != A.width || B.height != B.width || A.height != B.height || A.height % 2 != 0 {
+		return C.MulTransBBLAS(A, B)
+	}
+
+	n := A.height / 2
+	A11 := A.SubMatrix(0, 0, n, n)
+	A12 := A.SubMatrix(0, n, n, n)
+	A21 := A.SubMatrix(n, 0, n, n)
+	A22 := A.SubMatrix(n, n, n, n)
+	B11 := B.SubMatrix(0, 0, n, n)
+	B12 := B.SubMatrix(n, 0, n, n) // transpose(B)12 = transpose(B21)
+	B21 := B.SubMatrix(0, n, n, n) // transpose(B)21 = transpose(B12)
+	B22 := B.SubMatrix(n, n, n, n)
+	C11 := C.SubMatrix(0, 0, n, n)
+	C12 := C.SubMatrix(0, n, n, n)
+	C21 := C.SubMatrix(n, 0, n, n)
+	C22 := C.SubMatrix(n, n, n, n)
+
+	// Allocate scratch space
+	X := Zeros(n, n)
+	Y := Zeros(n, n)
+
+	// Perform calculations.
+	X.Minus(A11, A21)
+	Y.Minus(B22, B12)
+	C21.MulDouglasTransB(X, Y)
+	X.PlusBLAS(A21, A22)
+	Y.Minus(B12, B11)
+	C22.MulDouglasTransB(X, Y)
+	X.SubBLAS(A11)
+	Y.Minus(B22, Y)
+	C12.MulDouglasTransB(X, Y)
+	X.Minus(A12, X)
+	C11.MulDouglasTransB(X, B22)
+	X.MulDouglasTransB(A11, B11)
+	C12.AddBLAS(X)
+	C21.AddBLAS(C12)
+	C12.AddBLAS(C22)
+	C22.AddBLAS(C21) // Final c22.
+	C12.AddBLAS(C11) // Final c12.
+	Y.SubBLAS(B21)
+	C11.MulDouglasTransB(A22, Y)
+	C21.SubBLAS(C11) // Final c21.
+	C11.MulDouglasTransB(A12, B21)
+	C11.AddBLAS(X) // Final c11.
+	return C
+}
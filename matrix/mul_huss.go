@@ -8,11 +8,28 @@ func MulHuss(A, B *Matrix) *Matrix {
 	return Zeros(A.height, B.width).MulAddHuss(A, B)
 }
 
-// MulAddHuss returns C = C + A * B.
+// MulAddHuss calculates C = C + A * B and returns C.
 func (C *Matrix) MulAddHuss(A, B *Matrix) *Matrix {
+	return C.MulAddHussWorkspace(A, B, nil)
+}
+
+// MulAddHussWorkspace calculates C = C + A * B and returns C.
+//
+// Scratch space is taken from Workspace w, which may be nil.
+func (C *Matrix) MulAddHussWorkspace(A, B *Matrix, w *Workspace) *Matrix {
+	C.mulAddHuss(A, B, w, -1)
+	return C
+}
+
+// mulAddHuss calculates C = C + A * B with at most depth levels of recursion
+// (unlimited if depth < 0).
+//
+// It does not return C, see mulDouglas.
+func (C *Matrix) mulAddHuss(A, B *Matrix, w *Workspace, depth int) {
 
-	if A.width < 80 || A.height != A.width || A.height % 2 != 0 {
-		return C.MulAddBLAS(A, B)
+	if depth == 0 || A.width < 80 || A.height != A.width || A.height % 2 != 0 {
+		C.MulAddBLAS(A, B)
+		return
 	}
 
 	n := A.height / 2
@@ -30,33 +47,34 @@ func (C *Matrix) MulAddHuss(A, B *Matrix) *Matrix {
 	C22 := C.SubMatrix(n, n, n, n)
 
 	// Allocate scratch space
-	X := Zeros(n, n)
-	Y := Zeros(n, n)
-	Z := Zeros(n, n)
+	mark := w.mark()
+	X := w.get(n, n)
+	Y := w.get(n, n)
+	Z := w.get(n, n)
 
 	// Perform calculations.
 	X.PlusBLAS(A21, A22)
 	Y.Minus(B12, B11)
-	Z.MulAddHuss(X, Y)
+	Z.mulAddHuss(X, Y, w, depth - 1)
 	C22.AddBLAS(Z)
 	C12.AddBLAS(Z)
 	X.SubBLAS(A11)
 	Y.Minus(B22, Y)
 	Z.Clear()
-	Z.MulAddHuss(A11, B11)
+	Z.mulAddHuss(A11, B11, w, depth - 1)
 	C11.AddBLAS(Z)
-	Z.MulAddHuss(X, Y)
-	C11.MulAddHuss(A12, B21) // final C11
+	Z.mulAddHuss(X, Y, w, depth - 1)
+	C11.mulAddHuss(A12, B21, w, depth - 1) // final C11
 	X.Minus(A12, X)
 	Y.SubBLAS(B21)
-	C12.MulAddHuss(X, B22)
+	C12.mulAddHuss(X, B22, w, depth - 1)
 	C12.AddBLAS(Z) // final C12
 	C21.ScaleBLAS(-1)
-	C21.MulAddHuss(A22, Y)
+	C21.mulAddHuss(A22, Y, w, depth - 1)
 	X.Minus(A11, A21)
 	Y.Minus(B22, B12)
-	Z.MulAddHuss(X, Y)
+	Z.mulAddHuss(X, Y, w, depth - 1)
 	C22.AddBLAS(Z) // final C22
 	C21.Minus(Z, C21) // final C21
-	return C
+	w.release(mark)
 }
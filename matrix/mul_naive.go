@@ -51,3 +51,50 @@ func (C *Matrix) MulSubNaive(A, B *Matrix) *Matrix {
 	}
 	return C
 }
+
+// MulNaiveTransA returns transpose(A) * B.
+func MulNaiveTransA(A, B *Matrix) *Matrix {
+	return Zeros(A.width, B.width).MulNaiveTransA(A, B)
+}
+
+// MulNaiveTransA calculates C = transpose(A) * B and returns C.
+func (C *Matrix) MulNaiveTransA(A, B *Matrix) *Matrix {
+	for i := 0; i < C.height; i++ {
+		Ci := C.Row(i)
+		for k := range Ci {
+			Ci[k] = 0
+		}
+	}
+	// Row j of A and row j of B contribute their outer product.
+	for j := 0; j < A.height; j++ {
+		Bj := B.Row(j)
+		for i, aji := range A.Row(j) {
+			Ci := C.Row(i)
+			for k, bjk := range Bj {
+				Ci[k] += aji * bjk
+			}
+		}
+	}
+	return C
+}
+
+// MulNaiveTransB returns A * transpose(B).
+func MulNaiveTransB(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.height).MulNaiveTransB(A, B)
+}
+
+// MulNaiveTransB calculates C = A * transpose(B) and returns C.
+func (C *Matrix) MulNaiveTransB(A, B *Matrix) *Matrix {
+	for i := 0; i < A.height; i++ {
+		Ai := A.Row(i)
+		Ci := C.Row(i)
+		for k := range Ci {
+			var s float64
+			for j, bkj := range B.Row(k) {
+				s += Ai[j] * bkj
+			}
+			Ci[k] = s
+		}
+	}
+	return C
+}
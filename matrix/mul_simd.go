@@ -0,0 +1,54 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulSIMD returns A * B.
+//
+// C is calculated in 4 x 4 blocks with the gemm4x4 kernel, which uses AVX2
+// and FMA on amd64 and NEON on arm64. Rows and columns that do not fill a
+// block are calculated with the axpy kernel.
+func MulSIMD(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.width).MulAddSIMD(A, B)
+}
+
+// MulSIMD calculates C = A * B.
+func (C *Matrix) MulSIMD(A, B *Matrix) *Matrix {
+	C.Clear()
+	return C.MulAddSIMD(A, B)
+}
+
+// MulAddSIMD calculates C = C + A * B.
+func (C *Matrix) MulAddSIMD(A, B *Matrix) *Matrix {
+	m, n, k := A.height, B.width, A.width
+	m4, n4 := m &^ 3, n &^ 3
+
+	// Blocks are visited by column so a k x 4 strip of B stays in cache.
+	for j := 0; j < n4; j += 4 {
+		for i := 0; i < m4; i += 4 {
+			gemm4x4(k, A.data[i * A.stride:], A.stride, B.data[j:], B.stride,
+				C.data[i * C.stride + j:], C.stride)
+		}
+	}
+
+	// Columns to the right of the blocks.
+	if n4 < n {
+		for i := 0; i < m; i++ {
+			Ci := C.Row(i)[n4:]
+			for p, aip := range A.Row(i) {
+				axpy(aip, B.Row(p)[n4:], Ci)
+			}
+		}
+	}
+
+	// Rows below the blocks.
+	for i := m4; i < m; i++ {
+		Ci := C.Row(i)[:n4]
+		for p, aip := range A.Row(i) {
+			axpy(aip, B.Row(p)[:n4], Ci)
+		}
+	}
+
+	return C
+}
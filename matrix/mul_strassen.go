@@ -16,8 +16,14 @@ func MulStrassen(A, B *Matrix) *Matrix {
 
 // MulStrassen calculates C = A * B and returs C.
 func (C *Matrix) MulStrassen(A, B *Matrix) *Matrix {
+	return C.mulStrassen(A, B, -1)
+}
+
+// mulStrassen calculates C = A * B with at most depth levels of recursion
+// (unlimited if depth < 0) and returns C.
+func (C *Matrix) mulStrassen(A, B *Matrix, depth int) *Matrix {
 
-	if A.width < 80 || A.height != A.width || A.height % 2 != 0 {
+	if depth == 0 || A.width < 80 || A.height != A.width || A.height % 2 != 0 {
 		return C.MulBLAS(A, B)
 	}
 
@@ -35,13 +41,13 @@ func (C *Matrix) MulStrassen(A, B *Matrix) *Matrix {
 	C21 := C.SubMatrix(m, 0, m, m)
 	C22 := C.SubMatrix(m, m, m, m)
 
-	M1 := MulStrassen(Plus(A11, A22), Plus(B11, B22))
-	M2 := MulStrassen(Plus(A21, A22), B11)
-	M3 := MulStrassen(A11, Minus(B12, B22))
-	M4 := MulStrassen(A22, Minus(B21, B11))
-	M5 := MulStrassen(Plus(A11, A12), B22)
-	M6 := MulStrassen(Minus(A21, A11), Plus(B11, B12))
-	M7 := MulStrassen(Minus(A12, A22), Plus(B21, B22))
+	M1 := Zeros(m, m).mulStrassen(Plus(A11, A22), Plus(B11, B22), depth - 1)
+	M2 := Zeros(m, m).mulStrassen(Plus(A21, A22), B11, depth - 1)
+	M3 := Zeros(m, m).mulStrassen(A11, Minus(B12, B22), depth - 1)
+	M4 := Zeros(m, m).mulStrassen(A22, Minus(B21, B11), depth - 1)
+	M5 := Zeros(m, m).mulStrassen(Plus(A11, A12), B22, depth - 1)
+	M6 := Zeros(m, m).mulStrassen(Minus(A21, A11), Plus(B11, B12), depth - 1)
+	M7 := Zeros(m, m).mulStrassen(Minus(A12, A22), Plus(B21, B22), depth - 1)
 
 	C11.Add(M7).Add(M1).Add(M4).Sub(M5)
 	C12.Add(M5).Add(M3)
@@ -0,0 +1,99 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulStrassenTransA returns transpose(A) * B.
+//
+// This is MulStrassen with A read in transposed order, like MulDouglasTransA.
+func MulStrassenTransA(A, B *Matrix) *Matrix {
+	return Zeros(A.width, B.width).MulStrassenTransA(A, B)
+}
+
+// MulStrassenTransA calculates C = transpose(A) * B and returns C.
+func (C *Matrix) MulStrassenTransA(A, B *Matrix) *Matrix {
+
+	if A.width < 80 || A.height != A.width || A.height % 2 != 0 {
+		return C.MulTransABLAS(A, B)
+	}
+
+	m := A.height / 2
+	A11 := A.SubMatrix(0, 0, m, m)
+	A12 := A.SubMatrix(m, 0, m, m) // transpose(A)12 = transpose(A21)
+	A21 := A.SubMatrix(0, m, m, m) // transpose(A)21 = transpose(A12)
+	A22 := A.SubMatrix(m, m, m, m)
+	B11 := B.SubMatrix(0, 0, m, m)
+	B12 := B.SubMatrix(0, m, m, m)
+	B21 := B.SubMatrix(m, 0, m, m)
+	B22 := B.SubMatrix(m, m, m, m)
+	C11 := C.SubMatrix(0, 0, m, m)
+	C12 := C.SubMatrix(0, m, m, m)
+	C21 := C.SubMatrix(m, 0, m, m)
+	C22 := C.SubMatrix(m, m, m, m)
+
+	M1 := MulStrassenTransA(Plus(A11, A22), Plus(B11, B22))
+	M2 := MulStrassenTransA(Plus(A21, A22), B11)
+	M3 := MulStrassenTransA(A11, Minus(B12, B22))
+	M4 := MulStrassenTransA(A22, Minus(B21, B11))
+	M5 := MulStrassenTransA(Plus(A11, A12), B22)
+	M6 := MulStrassenTransA(Minus(A21, A11), Plus(B11, B12))
+	M7 := MulStrassenTransA(Minus(A12, A22), Plus(B21, B22))
+
+	C11.Copy(M7)
+	C11.Add(M1).Add(M4).Sub(M5)
+	C12.Copy(M5)
+	C12.Add(M3)
+	C21.Copy(M4)
+	C21.Add(M2)
+	C22.Copy(M6)
+	C22.Add(M1).Sub(M2).Add(M3)
+	return C
+}
+
+// MulStrassenTransB returns A * transpose(B).
+//
+// This is MulStrassen with B read in transposed order, like MulDouglasTransB.
+func MulStrassenTransB(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.height).MulStrassenTransB(A, B)
+}
+
+// MulStrassenTransB calculates C = A * transpose(B) and returns C.
+func (C *Matrix) MulStrassenTransB(A, B *Matrix) *Matrix {
+
+	if A.width < 80 || A.height != A.width || A.height % 2 != 0 {
+		return C.MulTransBBLAS(A, B)
+	}
+
+	m := A.height / 2
+	A11 := A.SubMatrix(0, 0, m, m)
+	A12 := A.SubMatrix(0, m, m, m)
+	A21 := A.SubMatrix(m, 0, m, m)
+	A22 := A.SubMatrix(m, m, m, m)
+	B11 := B.SubMatrix(0, 0, m, m)
+	B12 := B.SubMatrix(m, 0, m, m) // transpose(B)12 = transpose(B21)
+	B21 := B.SubMatrix(0, m, m, m) // transpose(B)21 = transpose(B12)
+	B22 := B.SubMatrix(m, m, m, m)
+	C11 := C.SubMatrix(0, 0, m, m)
+	C12 := C.SubMatrix(0, m, m, m)
+	C21 := C.SubMatrix(m, 0, m, m)
+	C22 := C.SubMatrix(m, m, m, m)
+
+	M1 := MulStrassenTransB(Plus(A11, A22), Plus(B11, B22))
+	M2 := MulStrassenTransB(Plus(A21, A22), B11)
+	M3 := MulStrassenTransB(A11, Minus(B12, B22))
+	M4 := MulStrassenTransB(A22, Minus(B21, B11))
+	M5 := MulStrassenTransB(Plus(A11, A12), B22)
+	M6 := MulStrassenTransB(Minus(A21, A11), Plus(B11, B12))
+	M7 := MulStrassenTransB(Minus(A12, A22), Plus(B21, B22))
+
+	C11.Copy(M7)
+	C11.Add(M1).Add(M4).Sub(M5)
+	C12.Copy(M5)
+	C12.Add(M3)
+	C21.Copy(M4)
+	C21.Add(M2)
+	C22.Copy(M6)
+	C22.Add(M1).Sub(M2).Add(M3)
+	return C
+}
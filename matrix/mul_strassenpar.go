@@ -4,14 +4,28 @@
 
 package matrix
 
+import (
+	"sync"
+)
+
 func MulStrassenPar(A, B * Matrix) *Matrix {
 	return Zeros(A.height, A.width).MulAddStrassenPar(A, B)
 }
 
 func (C *Matrix) MulAddStrassenPar(A, B *Matrix) *Matrix {
+	return C.MulAddStrassenParWorkspace(A, B, nil)
+}
+
+// MulAddStrassenParWorkspace is MulAddStrassenPar with scratch space taken
+// from Workspace w, which may be nil.
+//
+// The product is split into two halves that run in parallel if the pool of
+// SetParallelism has a goroutine left. Each half gets its own child workspace
+// of w.
+func (C *Matrix) MulAddStrassenParWorkspace(A, B *Matrix, w *Workspace) *Matrix {
 
 	if A.width < 200 || A.height != A.width || A.height % 2 != 0 {
-		return C.MulDouglas(A, B)
+		return C.MulDouglasWorkspace(A, B, w)
 	}
 
 	m := A.height / 2
@@ -28,35 +42,40 @@ func (C *Matrix) MulAddStrassenPar(A, B *Matrix) *Matrix {
 	C21 := C.SubMatrix(m, 0, m, m)
 	C22 := C.SubMatrix(m, m, m, m)
 
-	var M1, M2, M3 *Matrix
-	done1 := make(chan int)
-	done2 := make(chan int)
-
-	go func() {
-		M1 = Zeros(m, m)
-		M2 = Zeros(m, m)
-		M3 = Zeros(m, m)
-		M1.MulAddStrassenPar(Plus(A11, A22), Plus(B11, B22))
-		M2.MulAddStrassenPar(Plus(A21, A22), B11)
-		M3.MulAddStrassenPar(A11, Minus(B12, B22))
-		done1 <- 1
-	}()
+	mark := w.mark()
+	M1 := w.get(m, m)
+	M2 := w.get(m, m)
+	M3 := w.get(m, m)
+	var wg sync.WaitGroup
 
-	go func() {
-		C21.MulAddStrassenPar(A22, Minus(B21, B11))
-		C12.MulAddStrassenPar(Plus(A11, A12), B22)
-		C22.MulAddStrassenPar(Minus(A21, A11), Plus(B11, B12))
-		C11.MulAddStrassenPar(Minus(A12, A22), Plus(B21, B22))
-		done2 <- 1
-	}()
+	spawn(currentPool(), &wg, func() {
+		w1 := w.child(0)
+		mark := w1.mark()
+		X := w1.get(m, m)
+		Y := w1.get(m, m)
+		M1.MulAddStrassenParWorkspace(X.Plus(A11, A22), Y.Plus(B11, B22), w1)
+		M2.MulAddStrassenParWorkspace(X.Plus(A21, A22), B11, w1)
+		M3.MulAddStrassenParWorkspace(A11, Y.Minus(B12, B22), w1)
+		w1.release(mark)
+	})
 
-	// Wait for goroutines to finish.
-	<- done1
-	<- done2
+	func() {
+		w2 := w.child(1)
+		mark := w2.mark()
+		X := w2.get(m, m)
+		Y := w2.get(m, m)
+		C21.MulAddStrassenParWorkspace(A22, Y.Minus(B21, B11), w2)
+		C12.MulAddStrassenParWorkspace(X.Plus(A11, A12), B22, w2)
+		C22.MulAddStrassenParWorkspace(X.Minus(A21, A11), Y.Plus(B11, B12), w2)
+		C11.MulAddStrassenParWorkspace(X.Minus(A12, A22), Y.Plus(B21, B22), w2)
+		w2.release(mark)
+	}()
+	wg.Wait()
 
 	C11.AddBLAS(M1).AddBLAS(C21).SubBLAS(C12)
 	C12.AddBLAS(M3)
 	C21.AddBLAS(M2)
 	C22.AddBLAS(M1).SubBLAS(M2).AddBLAS(M3)
+	w.release(mark)
 	return C
 }
@@ -8,7 +8,6 @@ import (
 	"math"
 	"math/rand"
 	"testing"
-	"time"
 	//gomatrix "code.google.com/p/gomatrix/matrix"
 	gomatrix "harrydb1984-gomatrix/matrix"
 )
@@ -33,7 +32,9 @@ func TestMulBLAS(t *testing.T) {
 	C := MulNaive(A, B)
 	D := MulBLAS(A, B)
 
-	if !equal(C, D, 0, t) {
+	// The backend may use fused multiply-add instructions, which round
+	// differently from MulNaive.
+	if !equal(C, D, ε, t) {
 		t.FailNow()
 	}
 }
@@ -119,10 +120,10 @@ func TestGomatrix(t *testing.T) {
 
 func BenchmarkMulDouglas__1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -132,10 +133,10 @@ func BenchmarkMulDouglas__1024(bench *testing.B) {
 
 func BenchmarkMulHuss_____1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -145,10 +146,10 @@ func BenchmarkMulHuss_____1024(bench *testing.B) {
 
 func BenchmarkMulWinograd_1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -158,10 +159,10 @@ func BenchmarkMulWinograd_1024(bench *testing.B) {
 
 func BenchmarkMulStrassen_1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -171,10 +172,10 @@ func BenchmarkMulStrassen_1024(bench *testing.B) {
 
 func BenchmarkMulStrasPar_1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -184,11 +185,11 @@ func BenchmarkMulStrasPar_1024(bench *testing.B) {
 
 func BenchmarkMulGomatrix_1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -199,10 +200,10 @@ func BenchmarkMulGomatrix_1024(bench *testing.B) {
 
 func BenchmarkMulDouglas___512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -212,10 +213,10 @@ func BenchmarkMulDouglas___512(bench *testing.B) {
 
 func BenchmarkMulStrassPar_512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -225,10 +226,10 @@ func BenchmarkMulStrassPar_512(bench *testing.B) {
 
 func BenchmarkMulStrassen__512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -238,11 +239,11 @@ func BenchmarkMulStrassen__512(bench *testing.B) {
 
 func BenchmarkMulGomatrix__512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -253,10 +254,10 @@ func BenchmarkMulGomatrix__512(bench *testing.B) {
 
 func BenchmarkMulBLAS______512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -266,10 +267,10 @@ func BenchmarkMulBLAS______512(bench *testing.B) {
 
 func BenchmarkMulNaive____512(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 512
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -279,10 +280,10 @@ func BenchmarkMulNaive____512(bench *testing.B) {
 
 func BenchmarkMulDouglas___256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -292,10 +293,10 @@ func BenchmarkMulDouglas___256(bench *testing.B) {
 
 func BenchmarkMulHuss______256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -305,10 +306,10 @@ func BenchmarkMulHuss______256(bench *testing.B) {
 
 func BenchmarkMulStrassPar_256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -318,10 +319,10 @@ func BenchmarkMulStrassPar_256(bench *testing.B) {
 
 func BenchmarkMulStrassen__256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -331,10 +332,10 @@ func BenchmarkMulStrassen__256(bench *testing.B) {
 
 func BenchmarkMulBLAS______256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -344,11 +345,11 @@ func BenchmarkMulBLAS______256(bench *testing.B) {
 
 func BenchmarkMulGomatrix__256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -359,10 +360,10 @@ func BenchmarkMulGomatrix__256(bench *testing.B) {
 
 func BenchmarkMulNaive____256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -372,10 +373,10 @@ func BenchmarkMulNaive____256(bench *testing.B) {
 
 func BenchmarkMulStrassPar_128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -385,10 +386,10 @@ func BenchmarkMulStrassPar_128(bench *testing.B) {
 
 func BenchmarkMulDouglas___128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -398,10 +399,10 @@ func BenchmarkMulDouglas___128(bench *testing.B) {
 
 func BenchmarkMulStrassen__128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -411,10 +412,10 @@ func BenchmarkMulStrassen__128(bench *testing.B) {
 
 func BenchmarkMulBLAS______128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -424,11 +425,11 @@ func BenchmarkMulBLAS______128(bench *testing.B) {
 
 func BenchmarkMulGomatrix__128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -439,10 +440,10 @@ func BenchmarkMulGomatrix__128(bench *testing.B) {
 
 func BenchmarkMulNaive____128(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 128
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -452,10 +453,10 @@ func BenchmarkMulNaive____128(bench *testing.B) {
 
 func BenchmarkMulBLAS_______64(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -465,11 +466,11 @@ func BenchmarkMulBLAS_______64(bench *testing.B) {
 
 func BenchmarkMulGomatrix___64(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -480,10 +481,10 @@ func BenchmarkMulGomatrix___64(bench *testing.B) {
 
 func BenchmarkMulNaive_____64(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -493,10 +494,10 @@ func BenchmarkMulNaive_____64(bench *testing.B) {
 
 func BenchmarkMulBLAS_______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -506,11 +507,11 @@ func BenchmarkMulBLAS_______32(bench *testing.B) {
 
 func BenchmarkMulGomatrix___32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	a := randomMatrix(n, n)
+	a := RandomUniform(n, n, rnd)
 	A := gomatrix.MakeDenseMatrix(a.data, a.height, a.width)
-	b := randomMatrix(n, n)
+	b := RandomUniform(n, n, rnd)
 	B := gomatrix.MakeDenseMatrix(b.data, b.height, b.width)
 	bench.StartTimer()
 
@@ -521,10 +522,10 @@ func BenchmarkMulGomatrix___32(bench *testing.B) {
 
 func BenchmarkMulNaive_____32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 
 	for i := 0; i < bench.N; i++ {
@@ -0,0 +1,39 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulTransA returns transpose(A) * B.
+//
+// The transpose of A is never materialized, A is read in transposed order.
+// MulNaiveTransA, MulStrassenTransA, MulDouglasTransA and MulTransABLAS select
+// an algorithm, as MulNaive, MulStrassen, MulDouglas and MulBLAS do for Mul.
+func MulTransA(A, B *Matrix) *Matrix {
+	return Zeros(A.width, B.width).MulTransA(A, B)
+}
+
+// MulTransA calculates C = transpose(A) * B and returns C.
+func (C *Matrix) MulTransA(A, B *Matrix) *Matrix {
+	if A.height / 2 + A.width / 2 < 80 {
+		return C.MulTransABLAS(A, B)
+	}
+	return C.MulDouglasTransA(A, B)
+}
+
+// MulTransB returns A * transpose(B).
+//
+// The transpose of B is never materialized, B is read in transposed order.
+// MulNaiveTransB, MulStrassenTransB, MulDouglasTransB and MulTransBBLAS select
+// an algorithm, as MulNaive, MulStrassen, MulDouglas and MulBLAS do for Mul.
+func MulTransB(A, B *Matrix) *Matrix {
+	return Zeros(A.height, B.height).MulTransB(A, B)
+}
+
+// MulTransB calculates C = A * transpose(B) and returns C.
+func (C *Matrix) MulTransB(A, B *Matrix) *Matrix {
+	if A.height / 2 + A.width / 2 < 80 {
+		return C.MulTransBBLAS(A, B)
+	}
+	return C.MulDouglasTransB(A, B)
+}
@@ -0,0 +1,25 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulTransABLAS calculates C = transpose(A) * B.
+func (C *Matrix) MulTransABLAS(A, B *Matrix) *Matrix {
+	return C.gemm(true, false, 1, A, B, 0)
+}
+
+// MulAddTransABLAS calculates C = C + transpose(A) * B.
+func (C *Matrix) MulAddTransABLAS(A, B *Matrix) *Matrix {
+	return C.gemm(true, false, 1, A, B, 1)
+}
+
+// MulTransBBLAS calculates C = A * transpose(B).
+func (C *Matrix) MulTransBBLAS(A, B *Matrix) *Matrix {
+	return C.gemm(false, true, 1, A, B, 0)
+}
+
+// MulAddTransBBLAS calculates C = C + A * transpose(B).
+func (C *Matrix) MulAddTransBBLAS(A, B *Matrix) *Matrix {
+	return C.gemm(false, true, 1, A, B, 1)
+}
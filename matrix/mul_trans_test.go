@@ -0,0 +1,124 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMulTransABLAS(t *testing.T) {
+	A := randomMatrix(30, 20)
+	B := randomMatrix(30, 40)
+	C := MulNaive(Transpose(A), B)
+	D := Zeros(20, 40).MulTransABLAS(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulTransBBLAS(t *testing.T) {
+	A := randomMatrix(20, 30)
+	B := randomMatrix(40, 30)
+	C := MulNaive(A, Transpose(B))
+	D := Zeros(20, 40).MulTransBBLAS(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulTransA(t *testing.T) {
+	n := 200
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(Transpose(A), B)
+	D := MulTransA(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulTransB(t *testing.T) {
+	n := 200
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(A, Transpose(B))
+	D := MulTransB(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulDouglasTransSubMatrix(t *testing.T) {
+	n := 160
+	A := randomMatrix(n + 20, n + 10).SubMatrix(10, 5, n, n)
+	B := randomMatrix(n + 20, n + 10).SubMatrix(3, 7, n, n)
+	C := MulNaive(Transpose(A), B)
+	D := MulDouglasTransA(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+
+	C = MulNaive(A, Transpose(B))
+	D = MulDouglasTransB(A, B)
+
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulTransVariants(t *testing.T) {
+	A := randomMatrix(30, 20)
+	B := randomMatrix(30, 40)
+	if !equal(MulNaive(Transpose(A), B), MulNaiveTransA(A, B), ε, t) {
+		t.FailNow()
+	}
+	A = randomMatrix(20, 30)
+	B = randomMatrix(40, 30)
+	if !equal(MulNaive(A, Transpose(B)), MulNaiveTransB(A, B), ε, t) {
+		t.FailNow()
+	}
+
+	n := 160
+	A = randomMatrix(n + 20, n + 10).SubMatrix(10, 5, n, n)
+	B = randomMatrix(n + 20, n + 10).SubMatrix(3, 7, n, n)
+	if !equal(MulNaive(Transpose(A), B), MulStrassenTransA(A, B), ε, t) {
+		t.FailNow()
+	}
+	if !equal(MulNaive(A, Transpose(B)), MulStrassenTransB(A, B), ε, t) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkMulTransA___512(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 512
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		MulTransA(A, B)
+	}
+}
+
+func BenchmarkMulTransB___512(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 512
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		MulTransB(A, B)
+	}
+}
@@ -23,8 +23,14 @@ func MulWinograd(A, B *Matrix) *Matrix {
 
 // MulWinograd calculates C = A * B and returns C.
 func (C *Matrix) MulWinograd(A, B *Matrix) *Matrix {
+	return C.mulWinograd(A, B, -1)
+}
+
+// mulWinograd calculates C = A * B with at most depth levels of recursion
+// (unlimited if depth < 0) and returns C.
+func (C *Matrix) mulWinograd(A, B *Matrix, depth int) *Matrix {
 
-	if A.width < 80 || A.height != A.width || A.height % 2 != 0 {
+	if depth == 0 || A.width < 80 || A.height != A.width || A.height % 2 != 0 {
 		return C.MulBLAS(A, B)
 	}
 
@@ -53,14 +59,14 @@ func (C *Matrix) MulWinograd(A, B *Matrix) *Matrix {
 	T4 := Minus(B21, T2)
 
 	// 7 multiplications
-	C22.MulWinograd(S1, T1)
-	S1.MulWinograd(A11, B11)
-	C11.MulWinograd(A12, B21)
+	C22.mulWinograd(S1, T1, depth - 1)
+	S1.mulWinograd(A11, B11, depth - 1)
+	C11.mulWinograd(A12, B21, depth - 1)
 
-	C21.MulWinograd(A22, T4)
-	T4.MulWinograd(S2, T2)
-	S2.MulWinograd(S3, T3)
-	C12.MulWinograd(S4, B22)
+	C21.mulWinograd(A22, T4, depth - 1)
+	T4.mulWinograd(S2, T2, depth - 1)
+	S2.mulWinograd(S3, T3, depth - 1)
+	C12.mulWinograd(S4, B22, depth - 1)
 
 	// 7 additions
 	C11.AddBLAS(S1)
@@ -0,0 +1,69 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// MulOutOfCore calculates C = A * B in tiles and returns C. It is meant for
+// matrices that are too large for memory, such as those created with
+// CreateMapped, but works for any matrices.
+//
+// C is calculated one tile x tile block at a time. The blocks of A and B that
+// contribute to it are copied into memory one pair at a time and multiplied
+// with MulAdd, so only three tiles (24 * tile * tile bytes) are held in memory:
+// the copies of the blocks of A and B, and the block of C.
+//
+// The pages of memory-mapped operands are given back to the operating system
+// once they are no longer needed soon, which bounds the resident memory of the
+// process: those of a stripe of tile rows of A after all blocks of C in that
+// stripe are done, as every block of C reads the whole stripe, those of a
+// block of B right after it is copied, and those of a block of C after it is
+// written.
+func MulOutOfCore(C, A, B *Matrix, tile int) *Matrix {
+	if tile <= 0 {
+		panic("matrix.MulOutOfCore: tile size must be positive.")
+	}
+	m, k, n := A.height, A.width, B.width
+	if B.height != k || C.height != m || C.width != n {
+		panic("matrix.MulOutOfCore: dimensions do not match.")
+	}
+
+	a := Zeros(tile, tile)
+	b := Zeros(tile, tile)
+	c := Zeros(tile, tile)
+
+	for i := 0; i < m; i += tile {
+		mi := clip(tile, m - i)
+		for j := 0; j < n; j += tile {
+			nj := clip(tile, n - j)
+			cij := c.SubMatrix(0, 0, mi, nj)
+			cij.Clear()
+
+			for p := 0; p < k; p += tile {
+				kp := clip(tile, k - p)
+				aip := a.SubMatrix(0, 0, mi, kp)
+				bpj := b.SubMatrix(0, 0, kp, nj)
+				Aip := A.SubMatrix(i, p, mi, kp)
+				Bpj := B.SubMatrix(p, j, kp, nj)
+				aip.Copy(Aip)
+				bpj.Copy(Bpj)
+				release(Bpj)
+				cij.MulAdd(aip, bpj)
+			}
+
+			Cij := C.SubMatrix(i, j, mi, nj)
+			Cij.Copy(cij)
+			release(Cij)
+		}
+		release(A.SubMatrix(i, 0, mi, k))
+	}
+	return C
+}
+
+// clip returns the smaller of tile and rest.
+func clip(tile, rest int) int {
+	if rest < tile {
+		return rest
+	}
+	return tile
+}
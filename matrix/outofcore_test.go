@@ -0,0 +1,22 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"testing"
+)
+
+func TestMulOutOfCore(t *testing.T) {
+	A := randomMatrix(70, 45)
+	B := randomMatrix(45, 53)
+	C := MulNaive(A, B)
+
+	for _, tile := range []int{1, 16, 45, 100} {
+		D := MulOutOfCore(Zeros(70, 53), A, B, tile)
+		if !equal(C, D, ε, t) {
+			t.Fatalf("tile = %d", tile)
+		}
+	}
+}
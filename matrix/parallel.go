@@ -0,0 +1,114 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"runtime"
+	"sync"
+)
+
+// The element-wise operations (Add, Sub, Plus, Minus, Scale and Copy) split
+// large matrices into bands of rows, and MulStrassenPar runs its
+// sub-multiplications, in extra goroutines. All of them share one pool: at
+// most Parallelism() - 1 extra goroutines run at a time, besides the callers.
+// Work that finds the pool empty runs in the calling goroutine, so nested
+// parallel operations never wait for each other.
+
+// parallelThreshold is the number of elements below which the element-wise
+// operations are not split, starting goroutines costs more than they gain.
+const parallelThreshold = 1 << 16
+
+var (
+	poolMu sync.Mutex
+
+	// pool holds a token for every extra goroutine that may be started.
+	pool = newPool(runtime.GOMAXPROCS(0))
+)
+
+func newPool(n int) chan struct{} {
+	p := make(chan struct{}, n - 1)
+	for i := 0; i < n - 1; i++ {
+		p <- struct{}{}
+	}
+	return p
+}
+
+// SetParallelism sets the number of goroutines, including the caller, that
+// the package uses for one operation. If n < 1 it is set to GOMAXPROCS, and
+// n == 1 makes all operations serial.
+//
+// SetParallelism may be called at any time, operations that are running keep
+// the setting they started with.
+func SetParallelism(n int) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	poolMu.Lock()
+	pool = newPool(n)
+	poolMu.Unlock()
+}
+
+// Parallelism returns the number of goroutines the package uses for one
+// operation.
+func Parallelism() int {
+	return cap(currentPool()) + 1
+}
+
+func currentPool() chan struct{} {
+	poolMu.Lock()
+	p := pool
+	poolMu.Unlock()
+	return p
+}
+
+// spawn runs f in a new goroutine if the pool has a token left, and in the
+// calling goroutine otherwise. wg.Done is called when f returns.
+func spawn(p chan struct{}, wg *sync.WaitGroup, f func()) {
+	wg.Add(1)
+	select {
+	case <-p:
+		go func() {
+			f()
+			p <- struct{}{}
+			wg.Done()
+		}()
+	default:
+		f()
+		wg.Done()
+	}
+}
+
+// parallel reports whether an element-wise operation on A is split into bands:
+// A has more than one row and at least parallelThreshold elements.
+func parallel(A *Matrix) bool {
+	return A.height > 1 && A.height * A.width >= parallelThreshold
+}
+
+// inBands calls f for bands of rows [i0, i1) that together cover m rows, in as
+// many goroutines as the pool allows.
+//
+// Callers let f capture copies of their matrices, not the pointers: those
+// would escape to the heap even for small matrices, and the *Workspace
+// multiplications would no longer be free of allocations.
+func inBands(m int, f func(i0, i1 int)) {
+	p := currentPool()
+	n := cap(p) + 1
+	if n > m {
+		n = m
+	}
+
+	var wg sync.WaitGroup
+	for b := 1; b < n; b++ {
+		i0, i1 := b * m / n, (b + 1) * m / n
+		spawn(p, &wg, func() { f(i0, i1) })
+	}
+	f(0, m / n)
+	wg.Wait()
+}
+
+// band returns rows [i0, i1) of A.
+func (A *Matrix) band(i0, i1 int) *Matrix {
+	return A.SubMatrix(i0, 0, i1 - i0, A.width)
+}
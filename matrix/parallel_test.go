@@ -0,0 +1,134 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestSetParallelism(t *testing.T) {
+	defer SetParallelism(0)
+
+	SetParallelism(3)
+	if p := Parallelism(); p != 3 {
+		t.Errorf("Parallelism() = %d, want 3", p)
+	}
+	SetParallelism(0)
+	if p, want := Parallelism(), runtime.GOMAXPROCS(0); p != want {
+		t.Errorf("Parallelism() = %d, want GOMAXPROCS = %d", p, want)
+	}
+}
+
+// TestParallelElementWise compares the split operations with the serial ones
+// on submatrices that are large enough to be split.
+func TestParallelElementWise(t *testing.T) {
+	defer SetParallelism(0)
+
+	n := 400
+	A := randomMatrix(n, n).SubMatrix(3, 7, 333, 301)
+	B := randomMatrix(n, n).SubMatrix(11, 2, 333, 301)
+	if !parallel(A) {
+		t.Fatal("test matrices are below the threshold")
+	}
+
+	ops := map[string]func(C *Matrix){
+		"Add":   func(C *Matrix) { C.Add(A) },
+		"Sub":   func(C *Matrix) { C.Sub(A) },
+		"Plus":  func(C *Matrix) { C.Plus(A, B) },
+		"Minus": func(C *Matrix) { C.Minus(A, B) },
+		"Scale": func(C *Matrix) { C.Scale(-1.5) },
+		"Copy":  func(C *Matrix) { C.Copy(A) },
+	}
+	for name, op := range ops {
+		SetParallelism(1)
+		want := Zeros(n, n).SubMatrix(5, 5, 333, 301)
+		want.Copy(B)
+		op(want)
+
+		SetParallelism(5)
+		C := Zeros(n, n).SubMatrix(5, 5, 333, 301)
+		C.Copy(B)
+		op(C)
+		if !equal(want, C, 0, t) {
+			t.Fatalf("%s differs from the serial result", name)
+		}
+	}
+}
+
+// TestParallelNested runs element-wise operations from several goroutines
+// while MulStrassenPar exhausts the pool, they must run in the callers.
+func TestParallelNested(t *testing.T) {
+	defer SetParallelism(0)
+	SetParallelism(2)
+
+	n := 512
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	want := Plus(A, B)
+	want.Scale(2)
+
+	var wg sync.WaitGroup
+	Cs := make([]*Matrix, 4)
+	for k := range Cs {
+		Cs[k] = Zeros(n, n)
+		wg.Add(1)
+		go func(C *Matrix) {
+			defer wg.Done()
+			C.Copy(A)
+			C.Add(B)
+			C.Scale(2)
+		}(Cs[k])
+	}
+	C := MulNaive(A, B)
+	D := MulStrassenPar(A, B)
+	wg.Wait()
+	if !equal(C, D, ε, t) {
+		t.FailNow()
+	}
+	for _, C := range Cs {
+		if !equal(want, C, 0, t) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestCopySmaller(t *testing.T) {
+	n := 512
+	A := Zeros(n, n)
+	B := randomMatrix(n / 2, n)
+	A.Copy(B)
+	if !equal(B, A.SubMatrix(0, 0, n / 2, n), 0, t) {
+		t.FailNow()
+	}
+	if A.At(n - 1, n - 1) != 0 {
+		t.Error("Copy wrote beyond B")
+	}
+}
+
+func BenchmarkScale_______1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		A.Scale(1)
+	}
+}
+
+func BenchmarkCopy________1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := Zeros(n, n)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		B.Copy(A)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Pow returns A^k for a square matrix A and k >= 0.
+//
+// The power is calculated by repeated squaring, which takes at most
+// 2 log2(k) multiplications. All multiplications use Mul, so large matrices
+// are multiplied with one of the Strassen variants.
+func Pow(A *Matrix, k int) *Matrix {
+	if A.height != A.width {
+		panic("matrix.Pow: matrix is not square.")
+	}
+	if k < 0 {
+		panic("matrix.Pow: negative exponent.")
+	}
+
+	var R *Matrix
+	P := A
+	for {
+		if k & 1 == 1 {
+			if R == nil {
+				R = Zeros(A.height, A.width)
+				R.Copy(P)
+			} else {
+				R = Mul(R, P)
+			}
+		}
+		k >>= 1
+		if k == 0 {
+			break
+		}
+		P = Mul(P, P)
+	}
+
+	if R == nil {
+		return Identity(A.height)
+	}
+	return R
+}
@@ -0,0 +1,94 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/harrydb/go/matrix"
+	"github.com/harrydb/go/matrix/matrixtest"
+)
+
+type mulFunc func(A, B *matrix.Matrix) *matrix.Matrix
+
+// Variants that accept matrices of any size.
+var general = map[string]mulFunc{
+	"MulNaive":    matrix.MulNaive,
+	"MulBLAS":     matrix.MulBLAS,
+	"MulSIMD":     matrix.MulSIMD,
+	"MulAccurate": matrix.MulAccurate,
+}
+
+// Variants that need square matrices.
+var square = map[string]mulFunc{
+	"Mul":            matrix.Mul,
+	"MulStrassen":    matrix.MulStrassen,
+	"MulWinograd":    matrix.MulWinograd,
+	"MulDouglas":     matrix.MulDouglas,
+	"MulHuss":        matrix.MulHuss,
+	"MulStrassenPar": matrix.MulStrassenPar,
+}
+
+var tol = matrixtest.Tolerance{Abs: 1e-9, Rel: 1e-12}
+
+func check(t *testing.T, name string, f interface{}, count int) {
+	if err := quick.Check(f, &quick.Config{MaxCount: count}); err != nil {
+		t.Errorf("%s: %v", name, err)
+	}
+}
+
+func TestMulAssociative(t *testing.T) {
+	for name, mul := range general {
+		check(t, name, func(T matrixtest.Triple) bool {
+			return matrixtest.AssertEqual(t, mul(mul(T.A, T.B), T.C), mul(T.A, mul(T.B, T.C)), tol)
+		}, 20)
+	}
+	for name, mul := range merge(general, square) {
+		check(t, name, func(S matrixtest.SquareTriple) bool {
+			return matrixtest.AssertEqual(t, mul(mul(S.A, S.B), S.C), mul(S.A, mul(S.B, S.C)), tol)
+		}, 4)
+	}
+}
+
+func TestMulDistributive(t *testing.T) {
+	for name, mul := range merge(general, square) {
+		check(t, name, func(S matrixtest.SquareTriple) bool {
+			left := mul(S.A, matrix.Plus(S.B, S.C))
+			right := matrix.Plus(mul(S.A, S.B), mul(S.A, S.C))
+			return matrixtest.AssertEqual(t, left, right, tol)
+		}, 4)
+	}
+}
+
+func TestMulTranspose(t *testing.T) {
+	for name, mul := range general {
+		check(t, name, func(T matrixtest.Triple) bool {
+			left := matrix.Transpose(mul(T.A, T.B))
+			right := mul(matrix.Transpose(T.B), matrix.Transpose(T.A))
+			return matrixtest.AssertEqual(t, left, right, tol)
+		}, 20)
+	}
+	for name, mul := range merge(general, square) {
+		check(t, name, func(S matrixtest.SquareTriple) bool {
+			left := matrix.Transpose(mul(S.A, S.B))
+			right := mul(matrix.Transpose(S.B), matrix.Transpose(S.A))
+			return matrixtest.AssertEqual(t, left, right, tol) &&
+				matrixtest.AssertEqual(t, matrix.MulTransA(S.A, S.B), mul(matrix.Transpose(S.A), S.B), tol) &&
+				matrixtest.AssertEqual(t, matrix.MulTransB(S.A, S.B), mul(S.A, matrix.Transpose(S.B)), tol)
+		}, 4)
+	}
+}
+
+func merge(a, b map[string]mulFunc) map[string]mulFunc {
+	m := make(map[string]mulFunc)
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
@@ -0,0 +1,143 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"math/rand"
+)
+
+// The Random functions draw their numbers from rnd, so a sequence of random
+// matrices can be repeated by seeding rnd:
+//
+//	rnd := rand.New(rand.NewSource(42))
+//	A := matrix.RandomNormal(100, 100, rnd)
+//
+// If rnd is nil the global source of math/rand is used, which is safe for
+// concurrent use. A *rand.Rand is not.
+
+// RandomUniform returns an m x n matrix with elements drawn uniformly from
+// [0, 1).
+func RandomUniform(m, n int, rnd *rand.Rand) *Matrix {
+	A := Zeros(m, n)
+	fill(A.data, uniform(rnd))
+	return A
+}
+
+// RandomNormal returns an m x n matrix with elements drawn from the standard
+// normal distribution.
+func RandomNormal(m, n int, rnd *rand.Rand) *Matrix {
+	A := Zeros(m, n)
+	fill(A.data, normal(rnd))
+	return A
+}
+
+// RandomOrthogonal returns an n x n orthogonal matrix drawn from the uniform
+// (Haar) distribution over the orthogonal group.
+//
+// The rows of a matrix with standard normal elements are orthonormalised with
+// the Gram-Schmidt process. Because the norms are positive this is the QR
+// decomposition with a positive diagonal, which yields the Haar distribution.
+//
+//	F. Mezzadri, 2007.
+//	How to generate random matrices from the classical compact groups.
+//	http://arxiv.org/abs/math-ph/0609050
+func RandomOrthogonal(n int, rnd *rand.Rand) *Matrix {
+	Q := RandomNormal(n, n, rnd)
+	for i := 0; i < n; i++ {
+		Qi := Q.Row(i)
+		// Orthogonalise twice, once is not accurate enough for large n.
+		for pass := 0; pass < 2; pass++ {
+			for j := 0; j < i; j++ {
+				Qj := Q.Row(j)
+				backend.Daxpy(n, -backend.Ddot(n, Qi, 1, Qj, 1), Qj, 1, Qi, 1)
+			}
+		}
+		backend.Dscal(n, 1 / math.Sqrt(backend.Ddot(n, Qi, 1, Qi, 1)), Qi, 1)
+	}
+	return Q
+}
+
+// RandomSPD returns a random n x n symmetric positive definite matrix.
+//
+// The matrix is G * G' / n + I, where G has standard normal elements, so its
+// eigenvalues lie between 1 and about 5.
+func RandomSPD(n int, rnd *rand.Rand) *Matrix {
+	G := RandomNormal(n, n, rnd)
+	A := MulTransB(G, G)
+	A.Scale(1 / float64(n))
+	for i := 0; i < n; i++ {
+		// Make A exactly symmetric, whatever the backend.
+		Ai := A.Row(i)
+		for j := 0; j < i; j++ {
+			Ai[j] = A.At(j, i)
+		}
+		Ai[i] += 1
+	}
+	return A
+}
+
+// RandomSparse returns an m x n matrix in which each element is non-zero with
+// probability density. The non-zero elements are drawn from the standard
+// normal distribution.
+func RandomSparse(m, n int, density float64, rnd *rand.Rand) *Matrix {
+	if density < 0 || density > 1 {
+		panic("matrix.RandomSparse: density is not between 0 and 1.")
+	}
+	u, z := uniform(rnd), normal(rnd)
+	A := Zeros(m, n)
+	for i := range A.data {
+		if u() < density {
+			A.data[i] = z()
+		}
+	}
+	return A
+}
+
+// RandomCond returns a random n x n matrix with 2-norm condition number cond.
+//
+// The matrix is U * S * V', where U and V are drawn with RandomOrthogonal and
+// the singular values on the diagonal of S are spaced geometrically from 1 to
+// 1 / cond.
+func RandomCond(n int, cond float64, rnd *rand.Rand) *Matrix {
+	if cond < 1 {
+		panic("matrix.RandomCond: condition number is smaller than 1.")
+	}
+	U := RandomOrthogonal(n, rnd)
+	V := RandomOrthogonal(n, rnd)
+
+	// U = U * S
+	for j := 0; j < n; j++ {
+		σ := 1.0
+		if n > 1 {
+			σ = math.Pow(cond, -float64(j) / float64(n - 1))
+		}
+		backend.Dscal(n, σ, U.data[j:], U.stride)
+	}
+	return MulTransB(U, V)
+}
+
+// uniform returns the uniform generator of rnd, or of math/rand if rnd is nil.
+func uniform(rnd *rand.Rand) func() float64 {
+	if rnd == nil {
+		return rand.Float64
+	}
+	return rnd.Float64
+}
+
+// normal returns the normal generator of rnd, or of math/rand if rnd is nil.
+func normal(rnd *rand.Rand) func() float64 {
+	if rnd == nil {
+		return rand.NormFloat64
+	}
+	return rnd.NormFloat64
+}
+
+// fill sets the elements of x to values drawn from f.
+func fill(x []float64, f func() float64) {
+	for i := range x {
+		x[i] = f()
+	}
+}
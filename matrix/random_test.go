@@ -0,0 +1,136 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomSeed(t *testing.T) {
+	A := RandomNormal(10, 12, rand.New(rand.NewSource(7)))
+	B := RandomNormal(10, 12, rand.New(rand.NewSource(7)))
+	if !equal(A, B, 0, t) {
+		t.Fatal("same seed gives different matrices")
+	}
+	for _, x := range RandomUniform(30, 30, nil).data {
+		if x < 0 || x >= 1 {
+			t.Fatalf("uniform element %v outside [0, 1)", x)
+		}
+	}
+}
+
+func TestRandomOrthogonal(t *testing.T) {
+	n := 50
+	Q := RandomOrthogonal(n, rand.New(rand.NewSource(1)))
+	if !equal(Identity(n), MulTransB(Q, Q), 1e-13, t) {
+		t.Error("Q * Q' != I")
+	}
+	if !equal(Identity(n), MulTransA(Q, Q), 1e-13, t) {
+		t.Error("Q' * Q != I")
+	}
+}
+
+func TestRandomSPD(t *testing.T) {
+	n := 40
+	A := RandomSPD(n, rand.New(rand.NewSource(1)))
+	if !equal(A, Transpose(A), 0, t) {
+		t.Fatal("not symmetric")
+	}
+	λ, _ := eigenSym(A)
+	for _, λi := range λ {
+		if λi < 1 - 1e-12 {
+			t.Fatalf("eigenvalue %v < 1", λi)
+		}
+	}
+}
+
+func TestRandomSparse(t *testing.T) {
+	A := RandomSparse(100, 100, 0.1, rand.New(rand.NewSource(1)))
+	nz := 0
+	for _, x := range A.data {
+		if x != 0 {
+			nz++
+		}
+	}
+	if nz < 800 || nz > 1200 {
+		t.Errorf("%d non-zeros, expected about 1000", nz)
+	}
+	if RandomSparse(5, 5, 0, nil).data[7] != 0 {
+		t.Error("density 0 gives non-zeros")
+	}
+}
+
+func TestRandomCond(t *testing.T) {
+	n := 30
+	for _, cond := range []float64{1, 10, 1e6} {
+		A := RandomCond(n, cond, rand.New(rand.NewSource(1)))
+
+		// Not the eigenvalues of A' * A, which square the condition number
+		// and lose the accuracy of the small singular values.
+		min, max := math.Inf(1), 0.0
+		for _, σ := range singularValues(A) {
+			min = math.Min(min, σ)
+			max = math.Max(max, σ)
+		}
+		if c := max / min; math.Abs(c - cond) > 1e-6 * cond {
+			t.Errorf("condition number %v, want %v", c, cond)
+		}
+	}
+}
+
+// singularValues returns the singular values of A, unordered, using the
+// one-sided Jacobi method. It orthogonalizes the columns of A by rotations,
+// which determines even the small singular values to high relative accuracy.
+//
+//	Demmel and Veselić, 1992.
+//	Jacobi's method is more accurate than QR.
+//	SIAM J. Matrix Anal. Appl., 13(4), 1204-1245.
+func singularValues(A *Matrix) []float64 {
+	// The rows of G are the columns of A.
+	G := Transpose(A)
+	n := G.height
+
+	for sweep := 0; sweep < 64; sweep++ {
+		rotated := false
+		for p := 0; p < n - 1; p++ {
+			for q := p + 1; q < n; q++ {
+				Gp, Gq := G.Row(p), G.Row(q)
+				α, β, γ := 0.0, 0.0, 0.0
+				for k := range Gp {
+					α += Gp[k] * Gp[k]
+					β += Gq[k] * Gq[k]
+					γ += Gp[k] * Gq[k]
+				}
+				if math.Abs(γ) <= 1e-15 * math.Sqrt(α * β) {
+					continue
+				}
+				rotated = true
+
+				// Rotation that makes columns p and q orthogonal.
+				ζ := (β - α) / (2 * γ)
+				t := 1 / (math.Abs(ζ) + math.Sqrt(ζ * ζ + 1))
+				if ζ < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t * t + 1)
+				s := t * c
+				for k := range Gp {
+					Gp[k], Gq[k] = c * Gp[k] - s * Gq[k], s * Gp[k] + c * Gq[k]
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	σ := make([]float64, n)
+	for i := range σ {
+		σ[i] = math.Sqrt(dotGo(G.Row(i), G.Row(i)))
+	}
+	return σ
+}
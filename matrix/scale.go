@@ -4,7 +4,19 @@
 
 package matrix
 
+// Scale calculates A = v * A.
 func (A *Matrix) Scale(v float64) {
+	if parallel(A) {
+		a := *A
+		inBands(a.height, func(i0, i1 int) {
+			a.band(i0, i1).scale(v)
+		})
+		return
+	}
+	A.scale(v)
+}
+
+func (A *Matrix) scale(v float64) {
 
 	// Normal matrices.
 	if A.stride == A.width {
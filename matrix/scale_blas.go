@@ -4,18 +4,16 @@
 
 package matrix
 
-import "github.com/ziutek/blas"
-
 func (A *Matrix) ScaleBLAS(v float64) {
 
 	// Normal matrices.
 	if A.stride == A.width {
-		blas.Dscal(len(A.data), v, A.data, 1)
+		backend.Dscal(len(A.data), v, A.data, 1)
 		return
 	}
 
 	// Submatrices.
 	for i := 0; i < A.height; i++ {
-		blas.Dscal(A.width, v, A.Row(i), 1)
+		backend.Dscal(A.width, v, A.Row(i), 1)
 	}
 }
@@ -0,0 +1,121 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNotSPD is returned by Sqrtm when its argument is not symmetric positive
+// (semi)definite.
+var ErrNotSPD = errors.New("matrix: matrix is not symmetric positive definite")
+
+// Sqrtm returns the symmetric positive semidefinite square root S of the
+// symmetric positive semidefinite matrix A, such that S * S = A.
+//
+// The square root is calculated from the eigendecomposition A = V * D * V',
+// as S = V * sqrt(D) * V'. The eigendecomposition is found with the cyclic
+// Jacobi method, which is accurate but takes O(n^3) time per sweep.
+func Sqrtm(A *Matrix) (*Matrix, error) {
+	if A.height != A.width {
+		panic("matrix.Sqrtm: matrix is not square.")
+	}
+	n := A.height
+
+	// Check symmetry.
+	tol := 0.0
+	for i := 0; i < n; i++ {
+		for _, aij := range A.Row(i) {
+			tol = math.Max(tol, math.Abs(aij))
+		}
+	}
+	tol *= float64(n) * 1e-14
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if math.Abs(A.At(i, j) - A.At(j, i)) > tol {
+				return nil, ErrNotSPD
+			}
+		}
+	}
+
+	λ, V := eigenSym(A)
+
+	// W = V * sqrt(D)
+	W := Zeros(n, n)
+	W.Copy(V)
+	for j, λj := range λ {
+		if λj < -tol {
+			return nil, ErrNotSPD
+		}
+		s := math.Sqrt(math.Max(λj, 0))
+		for i := 0; i < n; i++ {
+			W.Set(i, j, W.At(i, j) * s)
+		}
+	}
+
+	return MulTransB(W, V), nil
+}
+
+// eigenSym returns the eigenvalues and eigenvectors (as columns) of the
+// symmetric matrix A, using the cyclic Jacobi method.
+func eigenSym(A *Matrix) ([]float64, *Matrix) {
+	n := A.height
+	S := Zeros(n, n)
+	S.Copy(A)
+	V := Identity(n)
+
+	for sweep := 0; sweep < 64; sweep++ {
+		// Stop when the off-diagonal part is negligible.
+		off, diag := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			Si := S.Row(i)
+			diag += Si[i] * Si[i]
+			for _, sij := range Si[i + 1:] {
+				off += sij * sij
+			}
+		}
+		if off <= 1e-32 * diag {
+			break
+		}
+
+		for p := 0; p < n - 1; p++ {
+			for q := p + 1; q < n; q++ {
+				spq := S.At(p, q)
+				if spq == 0 {
+					continue
+				}
+
+				// Rotation that zeroes S(p, q).
+				θ := (S.At(q, q) - S.At(p, p)) / (2 * spq)
+				t := 1 / (math.Abs(θ) + math.Sqrt(θ * θ + 1))
+				if θ < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t * t + 1)
+				s := t * c
+
+				// S = J' * S * J and V = V * J.
+				for k := 0; k < n; k++ {
+					Sk := S.Row(k)
+					Sk[p], Sk[q] = c * Sk[p] - s * Sk[q], s * Sk[p] + c * Sk[q]
+					Vk := V.Row(k)
+					Vk[p], Vk[q] = c * Vk[p] - s * Vk[q], s * Vk[p] + c * Vk[q]
+				}
+				Sp := S.Row(p)
+				Sq := S.Row(q)
+				for k := 0; k < n; k++ {
+					Sp[k], Sq[k] = c * Sp[k] - s * Sq[k], s * Sp[k] + c * Sq[k]
+				}
+			}
+		}
+	}
+
+	λ := make([]float64, n)
+	for i := range λ {
+		λ[i] = S.At(i, i)
+	}
+	return λ, V
+}
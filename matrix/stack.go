@@ -0,0 +1,188 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// HStack returns the matrices placed side by side: [A B ...]. The matrices
+// must have the same number of rows.
+func HStack(As ...*Matrix) *Matrix {
+	if len(As) == 0 {
+		return Zeros(0, 0)
+	}
+	m, n := As[0].height, 0
+	for _, A := range As {
+		if A.height != m {
+			panic("matrix.HStack: number of rows does not match.")
+		}
+		n += A.width
+	}
+
+	C := Zeros(m, n)
+	j := 0
+	for _, A := range As {
+		if !A.empty() {
+			C.SubMatrix(0, j, m, A.width).Copy(A)
+		}
+		j += A.width
+	}
+	return C
+}
+
+// VStack returns the matrices placed on top of each other: [A; B; ...]. The
+// matrices must have the same number of columns.
+func VStack(As ...*Matrix) *Matrix {
+	if len(As) == 0 {
+		return Zeros(0, 0)
+	}
+	m, n := 0, As[0].width
+	for _, A := range As {
+		if A.width != n {
+			panic("matrix.VStack: number of columns does not match.")
+		}
+		m += A.height
+	}
+
+	C := Zeros(m, n)
+	i := 0
+	for _, A := range As {
+		if !A.empty() {
+			C.SubMatrix(i, 0, A.height, n).Copy(A)
+		}
+		i += A.height
+	}
+	return C
+}
+
+// BlockDiag returns the block diagonal matrix with the given matrices on its
+// diagonal and zeros elsewhere. The matrices need not be square.
+func BlockDiag(As ...*Matrix) *Matrix {
+	m, n := 0, 0
+	for _, A := range As {
+		m += A.height
+		n += A.width
+	}
+
+	C := Zeros(m, n)
+	i, j := 0, 0
+	for _, A := range As {
+		if !A.empty() {
+			C.SubMatrix(i, j, A.height, A.width).Copy(A)
+		}
+		i += A.height
+		j += A.width
+	}
+	return C
+}
+
+// Augment returns the augmented matrix [A b], as used to solve A * x = b.
+func Augment(A *Matrix, b Vector) *Matrix {
+	if len(b) != A.height {
+		panic("matrix.Augment: length of b does not match the number of rows.")
+	}
+	return HStack(A, New(A.height, 1, b))
+}
+
+// Reshape returns an m x n matrix that shares its data with A, with the
+// elements in the same (row-major) order. A must be contiguous, which
+// submatrices that are narrower than their parent are not.
+func (A *Matrix) Reshape(m, n int) *Matrix {
+	if m * n != A.height * A.width {
+		panic("matrix.Reshape: number of elements does not match.")
+	}
+	if A.stride != A.width && A.height > 1 {
+		panic("matrix.Reshape: matrix is not contiguous.")
+	}
+	return &Matrix{m, n, n, A.data[:m * n]}
+}
+
+// SelectRows returns a new matrix with the rows of A at the given indices,
+// in the given order. An index may occur more than once.
+func (A *Matrix) SelectRows(rows []int) *Matrix {
+	C := Zeros(len(rows), A.width)
+	for i, r := range rows {
+		if r < 0 || r >= A.height {
+			panic("matrix.SelectRows: index out of range.")
+		}
+		copy(C.Row(i), A.Row(r))
+	}
+	return C
+}
+
+// SelectCols returns a new matrix with the columns of A at the given indices,
+// in the given order. An index may occur more than once.
+func (A *Matrix) SelectCols(cols []int) *Matrix {
+	for _, c := range cols {
+		if c < 0 || c >= A.width {
+			panic("matrix.SelectCols: index out of range.")
+		}
+	}
+	C := Zeros(A.height, len(cols))
+	for i := 0; i < A.height; i++ {
+		Ai, Ci := A.Row(i), C.Row(i)
+		for j, c := range cols {
+			Ci[j] = Ai[c]
+		}
+	}
+	return C
+}
+
+// ColView returns column j as an m x 1 matrix that shares its data with A.
+func (A *Matrix) ColView(j int) *Matrix {
+	if j < 0 || j >= A.width {
+		panic("matrix.ColView: index out of range.")
+	}
+	return A.SubMatrix(0, j, A.height, 1)
+}
+
+// Col returns a copy of column j.
+func (A *Matrix) Col(j int) Vector {
+	if j < 0 || j >= A.width {
+		panic("matrix.Col: index out of range.")
+	}
+	x := make(Vector, A.height)
+	for i := range x {
+		x[i] = A.data[i * A.stride + j]
+	}
+	return x
+}
+
+// ColViewStep returns the elements i, i + step, i + 2 * step, ... of column j
+// as a column matrix that shares its data with A.
+func (A *Matrix) ColViewStep(j, i, step int) *Matrix {
+	if j < 0 || j >= A.width || step < 1 || i < 0 || i > A.height {
+		panic("matrix.ColViewStep: invalid index or step.")
+	}
+	m := (A.height - i + step - 1) / step
+	if m == 0 {
+		return &Matrix{0, 1, A.stride * step, nil}
+	}
+	k := i * A.stride + j
+	return &Matrix{m, 1, A.stride * step, A.data[k : k + (m - 1) * step * A.stride + 1]}
+}
+
+// StepRows returns the rows i, i + step, i + 2 * step, ... of A as a matrix
+// that shares its data with A. Combined with ColView or SubMatrix this gives
+// a view with a step in both directions, e.g. every other element of column
+// j is A.StepRows(0, 2).ColView(j).
+//
+// There is no view with a step between columns, as the elements of a row are
+// always contiguous: the routines that operate on matrices depend on it. Use
+// SelectCols for a copy of every other column.
+func (A *Matrix) StepRows(i, step int) *Matrix {
+	if step < 1 || i < 0 || i > A.height {
+		panic("matrix.StepRows: invalid row or step.")
+	}
+	m := (A.height - i + step - 1) / step
+	if m == 0 {
+		return &Matrix{0, A.width, A.stride * step, nil}
+	}
+	return &Matrix{m, A.width, A.stride * step,
+		A.data[i * A.stride : (i + (m - 1) * step) * A.stride + A.width]}
+}
+
+// empty reports whether A has no elements, SubMatrix cannot create a view of
+// such a matrix.
+func (A *Matrix) empty() bool {
+	return A.height == 0 || A.width == 0
+}
@@ -0,0 +1,155 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"testing"
+)
+
+func TestHStackVStack(t *testing.T) {
+	A := New(2, 2, []float64{1, 2, 4, 5})
+	B := New(2, 1, []float64{3, 6})
+	C := HStack(A, B, Zeros(2, 0))
+	if !equal(C, New(2, 3, []float64{1, 2, 3, 4, 5, 6}), 0, t) {
+		t.Fatal("HStack")
+	}
+
+	D := VStack(C, New(1, 3, []float64{7, 8, 9}), Zeros(0, 3))
+	if !equal(D, New(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}), 0, t) {
+		t.Fatal("VStack")
+	}
+
+	// Submatrices as arguments.
+	E := VStack(D.SubMatrix(1, 1, 2, 2), D.SubMatrix(0, 0, 1, 2))
+	if !equal(E, New(3, 2, []float64{5, 6, 8, 9, 1, 2}), 0, t) {
+		t.Fatal("VStack of submatrices")
+	}
+}
+
+func TestStackMismatch(t *testing.T) {
+	for name, f := range map[string]func(){
+		"HStack": func() { HStack(Zeros(2, 2), Zeros(3, 2)) },
+		"VStack": func() { VStack(Zeros(2, 2), Zeros(2, 3)) },
+		"Augment": func() { Augment(Zeros(2, 2), Vector{1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s accepted mismatched dimensions", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestBlockDiag(t *testing.T) {
+	A := BlockDiag(New(1, 2, []float64{1, 2}), Identity(2), Zeros(0, 1))
+	want := New(3, 5, []float64{
+		1, 2, 0, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 0, 1, 0,
+	})
+	if !equal(A, want, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestAugment(t *testing.T) {
+	A := Augment(Identity(2), Vector{3, 4})
+	if !equal(A, New(2, 3, []float64{1, 0, 3, 0, 1, 4}), 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestReshape(t *testing.T) {
+	A := New(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	B := A.Reshape(3, 2)
+	if !equal(B, New(3, 2, []float64{1, 2, 3, 4, 5, 6}), 0, t) {
+		t.Fatal("Reshape")
+	}
+	B.Set(2, 1, 60)
+	if A.At(1, 2) != 60 {
+		t.Error("Reshape does not share its data")
+	}
+
+	// A single row of a submatrix is contiguous.
+	if C := A.SubMatrix(1, 0, 1, 2).Reshape(2, 1); C.At(1, 0) != 5 {
+		t.Errorf("Reshape of a row = %v", C)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Reshape accepted a matrix that is not contiguous")
+		}
+	}()
+	A.SubMatrix(0, 0, 2, 2).Reshape(1, 4)
+}
+
+func TestSelect(t *testing.T) {
+	A := New(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	if R := A.SelectRows([]int{2, 0, 2}); !equal(R, New(3, 3, []float64{7, 8, 9, 1, 2, 3, 7, 8, 9}), 0, t) {
+		t.Fatal("SelectRows")
+	}
+	if C := A.SubMatrix(1, 0, 2, 3).SelectCols([]int{1, 2, 1, 0}); !equal(C, New(2, 4, []float64{5, 6, 5, 4, 8, 9, 8, 7}), 0, t) {
+		t.Fatal("SelectCols")
+	}
+}
+
+func TestColView(t *testing.T) {
+	A := New(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	V := A.ColView(1)
+	if V.Rows() != 3 || V.Cols() != 1 || V.At(2, 0) != 8 {
+		t.Fatalf("ColView = %v", V)
+	}
+	V.Set(0, 0, 20)
+	if A.At(0, 1) != 20 {
+		t.Error("ColView does not share its data")
+	}
+	if x := A.Col(2); len(x) != 3 || x[0] != 3 || x[1] != 6 || x[2] != 9 {
+		t.Errorf("Col = %v", x)
+	}
+}
+
+func TestStepRows(t *testing.T) {
+	A := Zeros(7, 3)
+	for i := 0; i < 7; i++ {
+		A.Set(i, 0, float64(i))
+	}
+	S := A.StepRows(1, 3)
+	if S.Rows() != 2 || S.At(0, 0) != 1 || S.At(1, 0) != 4 {
+		t.Fatalf("StepRows(1, 3) = %v", S)
+	}
+	if S := A.StepRows(0, 2).ColView(0); S.Rows() != 4 || S.At(3, 0) != 6 {
+		t.Fatalf("StepRows(0, 2).ColView(0) = %v", S)
+	}
+	if S := A.StepRows(7, 2); S.Rows() != 0 {
+		t.Errorf("StepRows(7, 2) has %d rows", S.Rows())
+	}
+}
+
+func TestColViewStep(t *testing.T) {
+	A := Zeros(7, 3)
+	for i := 0; i < 7; i++ {
+		A.Set(i, 2, float64(i))
+	}
+	V := A.ColViewStep(2, 1, 2)
+	if V.Rows() != 3 || V.Cols() != 1 || V.At(0, 0) != 1 || V.At(2, 0) != 5 {
+		t.Fatalf("ColViewStep(2, 1, 2) = %v", V)
+	}
+	V.Set(1, 0, 30)
+	if A.At(3, 2) != 30 {
+		t.Error("ColViewStep does not share its data")
+	}
+	if x := V.Col(0); len(x) != 3 || x[2] != 5 {
+		t.Errorf("Col = %v", x)
+	}
+	if V := A.ColViewStep(0, 6, 4); V.Rows() != 1 {
+		t.Errorf("ColViewStep(0, 6, 4) has %d rows", V.Rows())
+	}
+	if V := A.ColViewStep(0, 7, 1); V.Rows() != 0 {
+		t.Errorf("ColViewStep(0, 7, 1) has %d rows", V.Rows())
+	}
+}
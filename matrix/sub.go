@@ -13,6 +13,17 @@ func Minus(A, B *Matrix) *Matrix {
 
 // Subtract calculates A = A - B and returns A.
 func (A *Matrix) Sub(B *Matrix) *Matrix {
+	if parallel(A) {
+		a, b := *A, *B
+		inBands(a.height, func(i0, i1 int) {
+			a.band(i0, i1).sub(b.band(i0, i1))
+		})
+		return A
+	}
+	return A.sub(B)
+}
+
+func (A *Matrix) sub(B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width {
@@ -34,6 +45,17 @@ func (A *Matrix) Sub(B *Matrix) *Matrix {
 
 // Minus calculates C = A - B and returns C.
 func (C *Matrix) Minus(A, B *Matrix) *Matrix {
+	if parallel(C) {
+		c, a, b := *C, *A, *B
+		inBands(c.height, func(i0, i1 int) {
+			c.band(i0, i1).minus(a.band(i0, i1), b.band(i0, i1))
+		})
+		return C
+	}
+	return C.minus(A, B)
+}
+
+func (C *Matrix) minus(A, B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width && C.stride == C.width {
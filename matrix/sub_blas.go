@@ -4,8 +4,6 @@
 
 package matrix
 
-import "github.com/ziutek/blas"
-
 func MinusBLAS(A, B *Matrix) *Matrix {
 	 C := Zeros(A.height, A.width)
 	 C.MinusBLAS(A, B)
@@ -17,13 +15,13 @@ func (A *Matrix) SubBLAS(B *Matrix) *Matrix {
 
 	// Normal matrices.
 	if A.stride == A.width && B.stride == B.width {
-		blas.Daxpy(len(A.data), -1.0, B.data, 1, A.data, 1)
+		backend.Daxpy(len(A.data), -1.0, B.data, 1, A.data, 1)
 		return A
 	}
 
 	// Submatrices.
 	for i := 0; i < A.height; i++ {
-		blas.Daxpy(A.width, -1.0, B.Row(i), 1, A.Row(i), 1)
+		backend.Daxpy(A.width, -1.0, B.Row(i), 1, A.Row(i), 1)
 	}
 	return A
 }
@@ -66,10 +66,10 @@ func TestSubSubmatrix(t *testing.T) {
 
 func BenchmarkSubBLAS_____1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.SubBLAS(B)
@@ -78,10 +78,10 @@ func BenchmarkSubBLAS_____1024(bench *testing.B) {
 
 func BenchmarkSub_________1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Sub(B)
@@ -90,10 +90,10 @@ func BenchmarkSub_________1024(bench *testing.B) {
 
 func BenchmarkMinusSBLAS___1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	C := Zeros(n, n)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
@@ -103,10 +103,10 @@ func BenchmarkMinusSBLAS___1024(bench *testing.B) {
 
 func BenchmarkMinusS_______1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	C := Zeros(n, n)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
@@ -116,10 +116,10 @@ func BenchmarkMinusS_______1024(bench *testing.B) {
 
 func BenchmarkMinusBLAS____1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		MinusBLAS(A, B)
@@ -128,10 +128,10 @@ func BenchmarkMinusBLAS____1024(bench *testing.B) {
 
 func BenchmarkMinus________1024(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 1024
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Minus(A, B)
@@ -140,10 +140,10 @@ func BenchmarkMinus________1024(bench *testing.B) {
 
 func BenchmarkSubBLAS______256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.SubBLAS(B)
@@ -152,10 +152,10 @@ func BenchmarkSubBLAS______256(bench *testing.B) {
 
 func BenchmarkSub__________256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Sub(B)
@@ -164,10 +164,10 @@ func BenchmarkSub__________256(bench *testing.B) {
 
 func BenchmarkMinusBLAS_____256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		MinusBLAS(A, B)
@@ -176,10 +176,10 @@ func BenchmarkMinusBLAS_____256(bench *testing.B) {
 
 func BenchmarkMinus_________256(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 256
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Minus(A, B)
@@ -188,10 +188,10 @@ func BenchmarkMinus_________256(bench *testing.B) {
 
 func BenchmarkSubBLAS_______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.SubBLAS(B)
@@ -200,10 +200,10 @@ func BenchmarkSubBLAS_______32(bench *testing.B) {
 
 func BenchmarkSub___________32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		A.Sub(B)
@@ -212,10 +212,10 @@ func BenchmarkSub___________32(bench *testing.B) {
 
 func BenchmarkMinusBLAS______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		MinusBLAS(A, B)
@@ -224,10 +224,10 @@ func BenchmarkMinusBLAS______32(bench *testing.B) {
 
 func BenchmarkMinus__________32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 32
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	bench.StartTimer()
 	for i := 0; i < bench.N; i++ {
 		Minus(A, B)
@@ -236,10 +236,10 @@ func BenchmarkMinus__________32(bench *testing.B) {
 
 func BenchmarkSubBLASSubM___32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(32, 32, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -250,10 +250,10 @@ func BenchmarkSubBLASSubM___32(bench *testing.B) {
 
 func BenchmarkSubSubM_______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -264,10 +264,10 @@ func BenchmarkSubSubM_______32(bench *testing.B) {
 
 func BenchmarkMinusBLASSubM__32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -278,10 +278,10 @@ func BenchmarkMinusBLASSubM__32(bench *testing.B) {
 
 func BenchmarkMinusSubM______32(bench *testing.B) {
 	bench.StopTimer()
-	rand.Seed(time.Now().Unix())
+	rnd := rand.New(rand.NewSource(1))
 	n := 64
-	A := randomMatrix(n, n)
-	B := randomMatrix(n, n)
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
 	A = A.SubMatrix(16, 16, 32, 32)
 	B = B.SubMatrix(0, 0, 32, 32)
 	bench.StartTimer()
@@ -289,3 +289,32 @@ func BenchmarkMinusSubM______32(bench *testing.B) {
 		Minus(A, B)
     }
 }
+
+func BenchmarkSubSerial___1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	SetParallelism(1)
+	defer SetParallelism(0)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		A.Sub(B)
+	}
+}
+
+func BenchmarkMinusSSerial1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	C := Zeros(n, n)
+	SetParallelism(1)
+	defer SetParallelism(0)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		C.Minus(A, B)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Triangular is an n x n upper or lower triangular matrix.
+//
+// Only the triangle is stored (packed row by row), which takes about half the
+// memory of a dense matrix. A unit triangular matrix has ones on the diagonal;
+// its stored diagonal is ignored.
+type Triangular struct {
+	n     int
+	upper bool
+	unit  bool
+	data  []float64
+}
+
+// NewTriangular returns a zero-filled n x n triangular matrix.
+func NewTriangular(n int, upper, unit bool) *Triangular {
+	return &Triangular{n, upper, unit, make([]float64, n * (n + 1) / 2)}
+}
+
+// TriangularFrom returns the upper or lower triangle of the square matrix A.
+func TriangularFrom(A *Matrix, upper, unit bool) *Triangular {
+	if A.height != A.width {
+		panic("matrix.TriangularFrom: matrix is not square.")
+	}
+	T := NewTriangular(A.height, upper, unit)
+	for i := 0; i < T.n; i++ {
+		j, Ti := T.row(i)
+		copy(Ti, A.Row(i)[j:])
+	}
+	return T
+}
+
+// Dense returns T as a full matrix.
+func (T *Triangular) Dense() *Matrix {
+	A := Zeros(T.n, T.n)
+	for i := 0; i < T.n; i++ {
+		j, Ti := T.row(i)
+		copy(A.Row(i)[j:], Ti)
+		if T.unit {
+			A.Set(i, i, 1)
+		}
+	}
+	return A
+}
+
+// row returns the stored part of row i and the column it starts at.
+func (T *Triangular) row(i int) (int, []float64) {
+	if T.upper {
+		offset := i * T.n - i * (i - 1) / 2
+		return i, T.data[offset : offset + T.n - i]
+	}
+	offset := i * (i + 1) / 2
+	return 0, T.data[offset : offset + i + 1]
+}
+
+// Size returns the number of rows (and columns).
+func (T *Triangular) Size() int {
+	return T.n
+}
+
+// Upper reports whether T is upper triangular.
+func (T *Triangular) Upper() bool {
+	return T.upper
+}
+
+// Unit reports whether T has an implicit unit diagonal.
+func (T *Triangular) Unit() bool {
+	return T.unit
+}
+
+// At returns the value of the matrix at row i and column j.
+func (T *Triangular) At(i, j int) float64 {
+	if i == j && T.unit {
+		return 1
+	}
+	if (T.upper && j < i) || (!T.upper && j > i) {
+		return 0
+	}
+	k, Ti := T.row(i)
+	return Ti[j - k]
+}
+
+// Set changes the value of the matrix at row i and column j, which must be
+// inside the triangle.
+func (T *Triangular) Set(i, j int, v float64) {
+	if (T.upper && j < i) || (!T.upper && j > i) {
+		panic("matrix.Triangular.Set: element is outside the triangle.")
+	}
+	k, Ti := T.row(i)
+	Ti[j - k] = v
+}
+
+// MulTri returns T * B.
+func MulTri(T *Triangular, B *Matrix) *Matrix {
+	return Zeros(T.n, B.width).MulTri(T, B)
+}
+
+// MulTri calculates C = T * B and returns C.
+func (C *Matrix) MulTri(T *Triangular, B *Matrix) *Matrix {
+	for i := 0; i < T.n; i++ {
+		Ci := C.Row(i)
+		for k := range Ci {
+			Ci[k] = 0
+		}
+		j, Ti := T.row(i)
+		for k, tij := range Ti {
+			if T.unit && j + k == i {
+				tij = 1
+			}
+			// Ci += tij * Bj
+			backend.Daxpy(C.width, tij, B.Row(j + k), 1, Ci, 1)
+		}
+	}
+	return C
+}
+
+// MulVecTri returns T * x.
+func MulVecTri(T *Triangular, x Vector) Vector {
+	return make(Vector, T.n).MulVecTri(T, x)
+}
+
+// MulVecTri calculates y = T * x and returns y.
+func (y Vector) MulVecTri(T *Triangular, x Vector) Vector {
+	for i := range y {
+		j, Ti := T.row(i)
+		y[i] = backend.Ddot(len(Ti), Ti, 1, x[j:], 1)
+		if T.unit {
+			y[i] += (1 - Ti[i - j]) * x[i]
+		}
+	}
+	return y
+}
+
+// SolveTri returns the solution X of T * X = B.
+func SolveTri(T *Triangular, B *Matrix) *Matrix {
+	X := Zeros(B.height, B.width)
+	X.Copy(B)
+	return X.SolveTri(T, X)
+}
+
+// SolveTri solves T * X = B by forward or back substitution and returns X.
+// X and B may be the same matrix.
+func (X *Matrix) SolveTri(T *Triangular, B *Matrix) *Matrix {
+	if X != B {
+		X.Copy(B)
+	}
+
+	for r := 0; r < T.n; r++ {
+		i := r
+		if T.upper {
+			i = T.n - 1 - r
+		}
+		j, Ti := T.row(i)
+		Xi := X.Row(i)
+
+		// Xi = (Bi - sum tik * Xk) / tii
+		for k, tik := range Ti {
+			if j + k != i {
+				backend.Daxpy(X.width, -tik, X.Row(j + k), 1, Xi, 1)
+			}
+		}
+		if !T.unit {
+			backend.Dscal(X.width, 1 / Ti[i - j], Xi, 1)
+		}
+	}
+	return X
+}
+
+// SolveVecTri returns the solution x of T * x = b.
+func SolveVecTri(T *Triangular, b Vector) Vector {
+	return make(Vector, T.n).SolveVecTri(T, b)
+}
+
+// SolveVecTri solves T * x = b and returns x. x and b may be the same vector.
+func (x Vector) SolveVecTri(T *Triangular, b Vector) Vector {
+	copy(x, b)
+	X := New(T.n, 1, x)
+	X.SolveTri(T, X)
+	return x
+}
@@ -0,0 +1,89 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"testing"
+)
+
+// randomTriangular returns a well-conditioned random triangular matrix.
+func randomTriangular(n int, upper, unit bool) *Triangular {
+	A := randomMatrix(n, n)
+	for i := 0; i < n; i++ {
+		A.Set(i, i, A.At(i, i) + 1)
+	}
+	return TriangularFrom(A, upper, unit)
+}
+
+func TestTriangularDense(t *testing.T) {
+	A := New(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	U := TriangularFrom(A, true, false).Dense()
+	correct := New(3, 3, []float64{1, 2, 3, 0, 5, 6, 0, 0, 9})
+	if !equal(U, correct, 0, t) {
+		t.FailNow()
+	}
+
+	L := TriangularFrom(A, false, true).Dense()
+	correct = New(3, 3, []float64{1, 0, 0, 4, 1, 0, 7, 8, 1})
+	if !equal(L, correct, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulTri(t *testing.T) {
+	n := 40
+	B := randomMatrix(n, 30)
+	for _, upper := range []bool{true, false} {
+		for _, unit := range []bool{true, false} {
+			T := randomTriangular(n, upper, unit)
+			C := MulNaive(T.Dense(), B)
+			D := MulTri(T, B)
+
+			if !equal(C, D, ε, t) {
+				t.Fatalf("Wrong result for upper = %v, unit = %v", upper, unit)
+			}
+		}
+	}
+}
+
+func TestMulVecTri(t *testing.T) {
+	n := 40
+	x := randomMatrix(n, 1)
+	for _, upper := range []bool{true, false} {
+		for _, unit := range []bool{true, false} {
+			T := randomTriangular(n, upper, unit)
+			C := MulNaive(T.Dense(), x)
+			y := MulVecTri(T, Vector(x.data))
+
+			if !equal(C, New(n, 1, y), ε, t) {
+				t.Fatalf("Wrong result for upper = %v, unit = %v", upper, unit)
+			}
+		}
+	}
+}
+
+func TestSolveTri(t *testing.T) {
+	n := 40
+	X := randomMatrix(n, 30)
+	for _, upper := range []bool{true, false} {
+		for _, unit := range []bool{true, false} {
+			T := randomTriangular(n, upper, unit)
+			B := MulNaive(T.Dense(), X)
+			Y := SolveTri(T, B)
+
+			if !equal(X, Y, 1e-10, t) {
+				t.Fatalf("Wrong result for upper = %v, unit = %v", upper, unit)
+			}
+
+			x := Vector(randomMatrix(n, 1).data)
+			b := MulVecTri(T, x)
+			b.SolveVecTri(T, b)
+			if !equal(New(n, 1, x), New(n, 1, b), 1e-10, t) {
+				t.Fatalf("Wrong vector result for upper = %v, unit = %v", upper, unit)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Vector is a dense column vector.
+//
+// A Vector is a plain []float64, so a row of a matrix (see Row) can be used as
+// a Vector without copying.
+type Vector []float64
+
+// MulVec returns A * x.
+func MulVec(A *Matrix, x Vector) Vector {
+	return make(Vector, A.height).MulVec(A, x)
+}
+
+// MulVec calculates y = A * x and returns y.
+func (y Vector) MulVec(A *Matrix, x Vector) Vector {
+	backend.Dgemv(false, A.height, A.width, 1, A.data, A.stride, x, 1, 0, y, 1)
+	return y
+}
+
+// MulAddVec calculates y = y + A * x and returns y.
+func (y Vector) MulAddVec(A *Matrix, x Vector) Vector {
+	backend.Dgemv(false, A.height, A.width, 1, A.data, A.stride, x, 1, 1, y, 1)
+	return y
+}
+
+// MulVecT returns transpose(A) * x.
+func MulVecT(A *Matrix, x Vector) Vector {
+	return make(Vector, A.width).MulAddVecT(A, x)
+}
+
+// MulVecT calculates y = transpose(A) * x and returns y.
+func (y Vector) MulVecT(A *Matrix, x Vector) Vector {
+	backend.Dgemv(true, A.height, A.width, 1, A.data, A.stride, x, 1, 0, y, 1)
+	return y
+}
+
+// MulAddVecT calculates y = y + transpose(A) * x and returns y.
+//
+// A is read row by row, so no transposed copy of A is made.
+func (y Vector) MulAddVecT(A *Matrix, x Vector) Vector {
+	backend.Dgemv(true, A.height, A.width, 1, A.data, A.stride, x, 1, 1, y, 1)
+	return y
+}
+
+// MulVecSym returns A * x for a symmetric matrix A.
+//
+// Only the upper triangle of A (including the diagonal) is read.
+func MulVecSym(A *Matrix, x Vector) Vector {
+	return make(Vector, A.height).MulVecSym(A, x)
+}
+
+// MulVecSym calculates y = A * x for a symmetric matrix A and returns y.
+//
+// Only the upper triangle of A (including the diagonal) is read.
+func (y Vector) MulVecSym(A *Matrix, x Vector) Vector {
+	n := A.height
+	for i := range y {
+		y[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		Ai := A.Row(i)
+		y[i] += Ai[i] * x[i]
+		if i+1 == n {
+			break
+		}
+		// The upper part of row i is also column i below the diagonal.
+		y[i] += backend.Ddot(n-i-1, Ai[i+1:], 1, x[i+1:], 1)
+		backend.Daxpy(n-i-1, x[i], Ai[i+1:], 1, y[i+1:], 1)
+	}
+	return y
+}
+
+// Rank1 calculates A = A + α * x * transpose(y) and returns A.
+func (A *Matrix) Rank1(α float64, x, y Vector) *Matrix {
+	backend.Dger(A.height, A.width, α, x, 1, y, 1, A.data, A.stride)
+	return A
+}
+
+// SolveLower returns the solution x of L * x = b.
+//
+// Only the lower triangle of L (including the diagonal) is read.
+func SolveLower(L *Matrix, b Vector) Vector {
+	return make(Vector, len(b)).SolveLower(L, b)
+}
+
+// SolveLower solves L * x = b by forward substitution and returns x.
+// x and b may be the same vector.
+//
+// Only the lower triangle of L (including the diagonal) is read.
+func (x Vector) SolveLower(L *Matrix, b Vector) Vector {
+	for i := range x {
+		Li := L.Row(i)
+		x[i] = (b[i] - backend.Ddot(i, Li, 1, x, 1)) / Li[i]
+	}
+	return x
+}
+
+// SolveUpper returns the solution x of U * x = b.
+//
+// Only the upper triangle of U (including the diagonal) is read.
+func SolveUpper(U *Matrix, b Vector) Vector {
+	return make(Vector, len(b)).SolveUpper(U, b)
+}
+
+// SolveUpper solves U * x = b by back substitution and returns x.
+// x and b may be the same vector.
+//
+// Only the upper triangle of U (including the diagonal) is read.
+func (x Vector) SolveUpper(U *Matrix, b Vector) Vector {
+	n := len(x)
+	for i := n - 1; i >= 0; i-- {
+		Ui := U.Row(i)
+		x[i] = (b[i] - backend.Ddot(n-i-1, Ui[i+1:], 1, x[i+1:], 1)) / Ui[i]
+	}
+	return x
+}
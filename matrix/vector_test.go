@@ -0,0 +1,161 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMulVec(t *testing.T) {
+	A := New(2, 3, []float64{1, 2, 4, 2, 1, 2})
+	x := Vector{8, 2, 1}
+	y := MulVec(A, x)
+
+	correct := New(2, 1, []float64{16, 20})
+	if !equal(New(2, 1, y), correct, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulVecNaive(t *testing.T) {
+	m, n := 50, 30
+	A := randomMatrix(m, n)
+	x := randomMatrix(n, 1)
+	C := MulNaive(A, x)
+	y := MulVec(A, Vector(x.data))
+
+	if !equal(C, New(m, 1, y), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulVecT(t *testing.T) {
+	m, n := 50, 30
+	A := randomMatrix(m, n)
+	x := randomMatrix(m, 1)
+	C := MulNaive(Transpose(A), x)
+	y := MulVecT(A, Vector(x.data))
+
+	if !equal(C, New(n, 1, y), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulVecSubMatrix(t *testing.T) {
+	A := randomMatrix(10, 10).SubMatrix(2, 3, 4, 5)
+	x := randomMatrix(5, 1)
+	C := MulNaive(A, x)
+	y := MulVec(A, Vector(x.data))
+
+	if !equal(C, New(4, 1, y), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestMulVecSym(t *testing.T) {
+	n := 40
+	A := randomMatrix(n, n)
+	S := Plus(A, Transpose(A))
+	x := randomMatrix(n, 1)
+	C := MulNaive(S, x)
+
+	// Scramble the lower triangle, it should not be read.
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			S.Set(i, j, rand.Float64())
+		}
+	}
+	y := MulVecSym(S, Vector(x.data))
+
+	if !equal(C, New(n, 1, y), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestRank1(t *testing.T) {
+	A := New(2, 3, []float64{1, 2, 4, 2, 1, 2})
+	A.Rank1(2, Vector{1, 2}, Vector{1, 0, 3})
+
+	correct := New(2, 3, []float64{3, 2, 10, 6, 1, 14})
+	if !equal(A, correct, 0, t) {
+		t.FailNow()
+	}
+}
+
+func TestRank1Naive(t *testing.T) {
+	m, n := 30, 20
+	A := randomMatrix(m, n)
+	x := randomMatrix(m, 1)
+	y := randomMatrix(1, n)
+	C := Plus(A, MulNaive(x, y))
+	A.Rank1(1, Vector(x.data), Vector(y.data))
+
+	if !equal(C, A, ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestSolveLower(t *testing.T) {
+	n := 30
+	L := randomMatrix(n, n)
+	for i := 0; i < n; i++ {
+		L.Set(i, i, L.At(i, i)+float64(n))
+		for j := i + 1; j < n; j++ {
+			L.Set(i, j, 0)
+		}
+	}
+	x := randomMatrix(n, 1)
+	b := MulVec(L, Vector(x.data))
+	b.SolveLower(L, b)
+
+	if !equal(x, New(n, 1, b), ε, t) {
+		t.FailNow()
+	}
+}
+
+func TestSolveUpper(t *testing.T) {
+	n := 30
+	U := randomMatrix(n, n)
+	for i := 0; i < n; i++ {
+		U.Set(i, i, U.At(i, i)+float64(n))
+		for j := 0; j < i; j++ {
+			U.Set(i, j, 0)
+		}
+	}
+	x := randomMatrix(n, 1)
+	b := MulVec(U, Vector(x.data))
+	y := SolveUpper(U, b)
+
+	if !equal(x, New(n, 1, y), ε, t) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkMulVec______1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	x := Vector(RandomUniform(n, 1, rnd).data)
+	y := make(Vector, n)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		y.MulVec(A, x)
+	}
+}
+
+func BenchmarkMulVecT_____1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	x := Vector(RandomUniform(n, 1, rnd).data)
+	y := make(Vector, n)
+	bench.StartTimer()
+	for i := 0; i < bench.N; i++ {
+		y.MulVecT(A, x)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+// Workspace holds scratch space for the recursive multiplication routines.
+//
+// MulDouglas, MulHuss and MulStrassenPar need temporary matrices at every
+// level of recursion. Normally these are allocated with Zeros, which creates a
+// lot of garbage for large matrices. The *Workspace variants of these
+// functions take their temporaries from a Workspace instead. Because the
+// temporaries are used in a stack-like fashion, a Workspace is a single slice
+// that is handed out and given back as the recursion descends and returns.
+//
+// A Workspace grows to the size needed by the largest multiplication it has
+// been used for, after that no more memory is allocated. A nil *Workspace is
+// valid and allocates every temporary with Zeros.
+//
+// MulStrassenPar still makes small allocations for its goroutines, but no
+// longer for its scratch matrices.
+//
+// A Workspace must not be used by more than one multiplication at a time.
+type Workspace struct {
+	data    []float64
+	headers []Matrix
+
+	// Scratch space currently handed out (may exceed the capacity).
+	used, count int
+
+	// Largest amount of scratch space handed out.
+	peak, peakCount int
+
+	// Workspaces for the goroutines of MulStrassenPar.
+	par [2]*Workspace
+}
+
+// workspaceMark records the state of a Workspace so it can be restored.
+type workspaceMark struct {
+	used, count int
+}
+
+// NewWorkspace returns a Workspace that is large enough to multiply n x n
+// matrices with MulDouglas or MulHuss without further allocations.
+func NewWorkspace(n int) *Workspace {
+	// MulHuss uses three n/2 x n/2 matrices per level: 3/4 n^2 + 3/16 n^2 + ...
+	// which is less than n^2 in total.
+	levels := 0
+	for m := n; m >= 80 && m % 2 == 0; m /= 2 {
+		levels++
+	}
+	return &Workspace{
+		data:    make([]float64, n * n),
+		headers: make([]Matrix, 3 * levels),
+	}
+}
+
+// get returns a zero-filled m x n matrix from the workspace.
+func (w *Workspace) get(m, n int) *Matrix {
+	if w == nil {
+		return Zeros(m, n)
+	}
+
+	start, k := w.used, w.count
+	w.used += m * n
+	w.count++
+	if w.used > w.peak {
+		w.peak = w.used
+	}
+	if w.count > w.peakCount {
+		w.peakCount = w.count
+	}
+
+	// Not enough space, the workspace grows when it is no longer in use.
+	if w.used > len(w.data) || k >= len(w.headers) {
+		return Zeros(m, n)
+	}
+
+	A := &w.headers[k]
+	*A = Matrix{m, n, n, w.data[start:w.used]}
+	A.Clear()
+	return A
+}
+
+// mark returns the current state of the workspace.
+func (w *Workspace) mark() workspaceMark {
+	if w == nil {
+		return workspaceMark{}
+	}
+	return workspaceMark{w.used, w.count}
+}
+
+// release gives back all matrices obtained with get since mark was called.
+func (w *Workspace) release(mark workspaceMark) {
+	if w == nil {
+		return
+	}
+	w.used, w.count = mark.used, mark.count
+
+	// Nothing is handed out anymore, so it is safe to grow.
+	if w.count == 0 {
+		if w.peak > len(w.data) {
+			w.data = make([]float64, w.peak)
+		}
+		if w.peakCount > len(w.headers) {
+			w.headers = make([]Matrix, w.peakCount)
+		}
+	}
+}
+
+// child returns the workspace to be used by goroutine i.
+func (w *Workspace) child(i int) *Workspace {
+	if w == nil {
+		return nil
+	}
+	if w.par[i] == nil {
+		w.par[i] = new(Workspace)
+	}
+	return w.par[i]
+}
@@ -0,0 +1,133 @@
+// Copyright 2012 Harry de Boer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMulDouglasWorkspace(t *testing.T) {
+	n := 320
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(A, B)
+	D := Zeros(n, n)
+	w := NewWorkspace(n)
+
+	// The second run reuses the workspace.
+	for i := 0; i < 2; i++ {
+		D.MulDouglasWorkspace(A, B, w)
+		if !equal(C, D, ε, t) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestMulHussWorkspace(t *testing.T) {
+	n := 320
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(A, B)
+	w := NewWorkspace(n)
+
+	for i := 0; i < 2; i++ {
+		D := Zeros(n, n).MulAddHussWorkspace(A, B, w)
+		if !equal(C, D, ε, t) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestMulStrassenParWorkspace(t *testing.T) {
+	n := 400
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := MulNaive(A, B)
+	D := Zeros(n, n)
+
+	// A workspace that is too small grows after the first run.
+	w := NewWorkspace(0)
+
+	for i := 0; i < 2; i++ {
+		D.Clear()
+		D.MulAddStrassenParWorkspace(A, B, w)
+		if !equal(C, D, ε, t) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestWorkspaceAllocs(t *testing.T) {
+	n := 320
+	A := randomMatrix(n, n)
+	B := randomMatrix(n, n)
+	C := Zeros(n, n)
+	w := NewWorkspace(n)
+
+	allocs := testing.AllocsPerRun(5, func() {
+		C.MulDouglasWorkspace(A, B, w)
+	})
+	if allocs != 0 {
+		t.Errorf("MulDouglasWorkspace: %v allocations, expected 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(5, func() {
+		C.MulAddHussWorkspace(A, B, w)
+	})
+	if allocs != 0 {
+		t.Errorf("MulAddHussWorkspace: %v allocations, expected 0", allocs)
+	}
+}
+
+func BenchmarkMulDouglasW_1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	C := Zeros(n, n)
+	w := NewWorkspace(n)
+	bench.ReportAllocs()
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		C.MulDouglasWorkspace(A, B, w)
+	}
+}
+
+func BenchmarkMulHussW____1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	C := Zeros(n, n)
+	w := NewWorkspace(n)
+	bench.ReportAllocs()
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		C.MulAddHussWorkspace(A, B, w)
+	}
+}
+
+func BenchmarkMulStrasParW1024(bench *testing.B) {
+	bench.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	n := 1024
+	A := RandomUniform(n, n, rnd)
+	B := RandomUniform(n, n, rnd)
+	C := Zeros(n, n)
+	w := NewWorkspace(n)
+	C.MulAddStrassenParWorkspace(A, B, w) // Grow the workspace.
+	bench.ReportAllocs()
+	bench.StartTimer()
+
+	for i := 0; i < bench.N; i++ {
+		C.Clear()
+		C.MulAddStrassenParWorkspace(A, B, w)
+	}
+}